@@ -4,6 +4,7 @@ import (
 	"exporter/parser"
 	"fmt"
 	"log"
+	"regexp"
 	"sync"
 	"time"
 
@@ -114,3 +115,70 @@ func (manager *CollectorManager) Collect(ch chan<- prometheus.Metric) {
 	}
 	wg.Wait()
 }
+
+// flagRegexp compiles a regular expression taken from a command line flag.
+// Flags are parsed after the collectors register themselves, so the pattern
+// is compiled on first use rather than at init time.
+type flagRegexp struct {
+	pattern *string
+	once    sync.Once
+	re      *regexp.Regexp
+	err     error
+}
+
+func newFlagRegexp(pattern *string) *flagRegexp {
+	return &flagRegexp{pattern: pattern}
+}
+
+func (r *flagRegexp) regexp() (*regexp.Regexp, error) {
+	r.once.Do(func() {
+		r.re, r.err = regexp.Compile(*r.pattern)
+		if r.err != nil {
+			r.err = fmt.Errorf("invalid pattern %q: %w", *r.pattern, r.err)
+		}
+	})
+	return r.re, r.err
+}
+
+// nameFilter selects names by a pair of include and exclude flag patterns.
+// An empty include pattern accepts every name, an empty exclude pattern
+// rejects none.
+type nameFilter struct {
+	include *flagRegexp
+	exclude *flagRegexp
+}
+
+func newNameFilter(include, exclude *string) *nameFilter {
+	return &nameFilter{
+		include: newFlagRegexp(include),
+		exclude: newFlagRegexp(exclude),
+	}
+}
+
+func (f *nameFilter) ignored(name string) (bool, error) {
+	include, exclude, err := f.patterns()
+	if err != nil {
+		return false, err
+	}
+
+	if include != nil && !include.MatchString(name) {
+		return true, nil
+	}
+	return exclude != nil && exclude.MatchString(name), nil
+}
+
+// patterns returns the compiled include and exclude patterns, nil for the
+// ones left empty.
+func (f *nameFilter) patterns() (include, exclude *regexp.Regexp, err error) {
+	if *f.include.pattern != "" {
+		if include, err = f.include.regexp(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if *f.exclude.pattern != "" {
+		if exclude, err = f.exclude.regexp(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return include, exclude, nil
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(ARPCollector))
+}
+
+type ARPCollector struct{}
+
+func (collector *ARPCollector) GetName() string {
+	return "arp"
+}
+
+func (collector *ARPCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- arpEntriesDesc
+	return nil
+}
+
+func (collector *ARPCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	filter, err := netDevFilter()
+	if err != nil {
+		return fmt.Errorf("network device filter: %s", err.Error())
+	}
+
+	entries, err := p.ParseARP(filter)
+	if err != nil {
+		return fmt.Errorf("parse arp metric failed, error: %s", err.Error())
+	}
+
+	type key struct{ device, state string }
+	counts := map[key]float64{}
+	for _, entry := range entries {
+		counts[key{entry.Device, entry.State}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(arpEntriesDesc, prometheus.GaugeValue, count, k.device, k.state)
+	}
+
+	return nil
+}
+
+var arpEntriesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName("node", "arp", "entries"),
+	"Number of IPv4 neighbour entries by network device and NUD state.",
+	[]string{"device", "state"}, nil,
+)
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(BondingCollector))
+}
+
+type BondingCollector struct{}
+
+func (collector *BondingCollector) GetName() string {
+	return "bonding"
+}
+
+func (collector *BondingCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- bondingInfoDesc
+	ch <- bondingSlavesDesc
+	ch <- bondingActiveDesc
+	ch <- bondingSlaveInfoDesc
+	ch <- bondingSlaveUpDesc
+	ch <- bondingSlaveLinkFailuresDesc
+	return nil
+}
+
+func (collector *BondingCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	filter, err := netDevFilter()
+	if err != nil {
+		return fmt.Errorf("network device filter: %s", err.Error())
+	}
+
+	bonds, err := p.ParseBonding(filter)
+	if err != nil {
+		return fmt.Errorf("parse bonding metric failed, error: %s", err.Error())
+	}
+
+	for _, bond := range bonds {
+		var active float64
+		for _, slave := range bond.Slaves {
+			up := 0.0
+			if slave.MIIStatus == "up" {
+				up = 1
+				active++
+			}
+
+			ch <- prometheus.MustNewConstMetric(bondingSlaveInfoDesc, prometheus.GaugeValue, 1,
+				bond.Name, slave.Name, slave.State, slave.MIIStatus)
+			ch <- prometheus.MustNewConstMetric(bondingSlaveUpDesc, prometheus.GaugeValue, up, bond.Name, slave.Name)
+			if slave.LinkFailures != nil {
+				ch <- prometheus.MustNewConstMetric(bondingSlaveLinkFailuresDesc, prometheus.CounterValue,
+					*slave.LinkFailures, bond.Name, slave.Name)
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(bondingInfoDesc, prometheus.GaugeValue, 1, bond.Name, bond.Mode, bond.MIIStatus)
+		ch <- prometheus.MustNewConstMetric(bondingSlavesDesc, prometheus.GaugeValue, float64(len(bond.Slaves)), bond.Name)
+		ch <- prometheus.MustNewConstMetric(bondingActiveDesc, prometheus.GaugeValue, active, bond.Name)
+	}
+
+	return nil
+}
+
+var (
+	bondingInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "info"),
+		"Bonding master mode and MII status, value is always 1.",
+		[]string{"master", "mode", "mii_status"}, nil,
+	)
+	bondingSlavesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "slaves"),
+		"Number of configured slaves of the bond.",
+		[]string{"master"}, nil,
+	)
+	bondingActiveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "active"),
+		"Number of slaves of the bond whose MII status is up.",
+		[]string{"master"}, nil,
+	)
+	bondingSlaveInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "slave_info"),
+		"Bond slave state (active or backup) and MII status, value is always 1.",
+		[]string{"master", "slave", "state", "mii_status"}, nil,
+	)
+	bondingSlaveUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "slave_up"),
+		"Value is 1 if the MII status of the bond slave is up, 0 otherwise.",
+		[]string{"master", "slave"}, nil,
+	)
+	bondingSlaveLinkFailuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bonding", "slave_link_failures_total"),
+		"Number of link failures of the bond slave.",
+		[]string{"master", "slave"}, nil,
+	)
+)
@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(BridgeCollector))
+}
+
+type BridgeCollector struct{}
+
+func (collector *BridgeCollector) GetName() string {
+	return "bridge"
+}
+
+func (collector *BridgeCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- bridgeSTPEnabledDesc
+	ch <- bridgePortsDesc
+	ch <- bridgePortStateDesc
+	return nil
+}
+
+func (collector *BridgeCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	filter, err := netDevFilter()
+	if err != nil {
+		return fmt.Errorf("network device filter: %s", err.Error())
+	}
+
+	bridges, err := p.ParseBridges(filter)
+	if err != nil {
+		return fmt.Errorf("parse bridge metric failed, error: %s", err.Error())
+	}
+
+	for _, bridge := range bridges {
+		if bridge.STPEnabled != nil {
+			ch <- prometheus.MustNewConstMetric(bridgeSTPEnabledDesc, prometheus.GaugeValue, boolValue(*bridge.STPEnabled), bridge.Name)
+		}
+		ch <- prometheus.MustNewConstMetric(bridgePortsDesc, prometheus.GaugeValue, float64(len(bridge.Ports)), bridge.Name)
+
+		for _, port := range bridge.Ports {
+			known := false
+			for _, state := range parser.BridgePortStates {
+				known = known || state == port.State
+				ch <- prometheus.MustNewConstMetric(bridgePortStateDesc, prometheus.GaugeValue, boolValue(state == port.State),
+					bridge.Name, port.Name, state)
+			}
+			// States of newer kernels are reported by their value.
+			if !known && port.State != "" {
+				ch <- prometheus.MustNewConstMetric(bridgePortStateDesc, prometheus.GaugeValue, 1,
+					bridge.Name, port.Name, port.State)
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	bridgeSTPEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bridge", "stp_enabled"),
+		"Value is 1 if STP is enabled on the bridge, 0 otherwise.",
+		[]string{"bridge"}, nil,
+	)
+	bridgePortsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bridge", "ports"),
+		"Number of ports attached to the bridge.",
+		[]string{"bridge"}, nil,
+	)
+	bridgePortStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "bridge", "port_state"),
+		"STP state of the bridge port, 1 for the current state.",
+		[]string{"bridge", "port", "state"}, nil,
+	)
+)
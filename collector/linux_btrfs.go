@@ -0,0 +1,143 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs/btrfs"
+)
+
+func init() {
+	registerCollector(new(BtrfsCollector))
+}
+
+type BtrfsCollector struct{}
+
+func (collector *BtrfsCollector) GetName() string {
+	return "btrfs"
+}
+
+func (collector *BtrfsCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- btrfsInfoDesc
+	ch <- btrfsGlobalRsvSizeDesc
+	ch <- btrfsReservedDesc
+	ch <- btrfsSizeDesc
+	ch <- btrfsUsedDesc
+	ch <- btrfsAllocationRatioDesc
+	ch <- btrfsDeviceSizeDesc
+	ch <- btrfsDeviceErrorsDesc
+	return nil
+}
+
+func (collector *BtrfsCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	stats, err := p.ParseBtrfsStats()
+	if err != nil {
+		return fmt.Errorf("parse btrfs metric failed, error: %s", err.Error())
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	mountPoints, err := mountPointsByBlockDevice(p)
+	if err != nil {
+		return fmt.Errorf("parse mountinfo failed, error: %s", err.Error())
+	}
+
+	for _, stat := range stats {
+		var mountPoint string
+		for device := range stat.Devices {
+			if mountPoint = mountPoints[device]; mountPoint != "" {
+				break
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(btrfsInfoDesc, prometheus.GaugeValue, 1, stat.UUID, stat.Label, mountPoint)
+		ch <- prometheus.MustNewConstMetric(btrfsGlobalRsvSizeDesc, prometheus.GaugeValue,
+			float64(stat.Allocation.GlobalRsvSize), stat.UUID)
+
+		for blockGroupType, allocation := range map[string]*btrfs.AllocationStats{
+			"data":     stat.Allocation.Data,
+			"metadata": stat.Allocation.Metadata,
+			"system":   stat.Allocation.System,
+		} {
+			if allocation == nil {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(btrfsReservedDesc, prometheus.GaugeValue,
+				float64(allocation.ReservedBytes), stat.UUID, blockGroupType)
+
+			for mode, layout := range allocation.Layouts {
+				ch <- prometheus.MustNewConstMetric(btrfsSizeDesc, prometheus.GaugeValue,
+					float64(layout.TotalBytes), stat.UUID, blockGroupType, mode)
+				ch <- prometheus.MustNewConstMetric(btrfsUsedDesc, prometheus.GaugeValue,
+					float64(layout.UsedBytes), stat.UUID, blockGroupType, mode)
+				ch <- prometheus.MustNewConstMetric(btrfsAllocationRatioDesc, prometheus.GaugeValue,
+					layout.Ratio, stat.UUID, blockGroupType, mode)
+			}
+		}
+
+		for name, device := range stat.Devices {
+			ch <- prometheus.MustNewConstMetric(btrfsDeviceSizeDesc, prometheus.GaugeValue,
+				float64(device.Size), stat.UUID, name)
+		}
+
+		for devID, errors := range stat.DeviceErrors {
+			for errorType, value := range errors {
+				ch <- prometheus.MustNewConstMetric(btrfsDeviceErrorsDesc, prometheus.CounterValue,
+					value, stat.UUID, devID, errorType)
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	btrfsInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "info"),
+		"Filesystem information, value is always 1.",
+		[]string{"uuid", "label", "mountpoint"}, nil,
+	)
+
+	btrfsGlobalRsvSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "global_rsv_size_bytes"),
+		"Size of the global reserve in bytes.",
+		[]string{"uuid"}, nil,
+	)
+
+	btrfsReservedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "reserved_bytes"),
+		"Amount of space reserved for a block group type in bytes.",
+		[]string{"uuid", "block_group_type"}, nil,
+	)
+
+	btrfsSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "size_bytes"),
+		"Amount of space allocated for a block group type and profile in bytes.",
+		[]string{"uuid", "block_group_type", "mode"}, nil,
+	)
+
+	btrfsUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "used_bytes"),
+		"Amount of used space by a block group type and profile in bytes.",
+		[]string{"uuid", "block_group_type", "mode"}, nil,
+	)
+
+	btrfsAllocationRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "allocation_ratio"),
+		"Data allocation ratio of a block group type and profile.",
+		[]string{"uuid", "block_group_type", "mode"}, nil,
+	)
+
+	btrfsDeviceSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "device_size_bytes"),
+		"Size of a device that is part of the filesystem in bytes.",
+		[]string{"uuid", "device"}, nil,
+	)
+
+	btrfsDeviceErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "btrfs", "device_errors_total"),
+		"Errors reported for a device of the filesystem, by error type.",
+		[]string{"uuid", "devid", "type"}, nil,
+	)
+)
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(BuddyInfoCollector))
+}
+
+type BuddyInfoCollector struct{}
+
+var (
+	buddyInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "buddyinfo", "blocks"),
+		"Count of free blocks according to their order.",
+		[]string{"node", "zone", "order"}, nil,
+	)
+)
+
+func (collector *BuddyInfoCollector) GetName() string {
+	return "buddyinfo"
+}
+
+func (collector *BuddyInfoCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- buddyInfoDesc
+	return nil
+}
+
+func (collector *BuddyInfoCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	path := "cat"
+	args := []string{"/proc/buddyinfo"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get buddyinfo metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseBuddyInfo(bytes)
+	if err != nil {
+		return fmt.Errorf("parse buddyinfo metric failed, error: %s", err.Error())
+	}
+
+	for _, stat := range stats {
+		for order, blocks := range stat.Blocks {
+			ch <- prometheus.MustNewConstMetric(buddyInfoDesc, prometheus.GaugeValue, blocks,
+				stat.Node, stat.Zone, strconv.Itoa(order))
+		}
+	}
+
+	return nil
+}
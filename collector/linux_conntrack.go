@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(ConntrackCollector))
+}
+
+type ConntrackCollector struct{}
+
+func (collector *ConntrackCollector) GetName() string {
+	return "conntrack"
+}
+
+func (collector *ConntrackCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- conntrackEntriesDesc
+	ch <- conntrackEntriesLimitDesc
+	for _, field := range conntrackStatFields {
+		ch <- field.desc
+	}
+	return nil
+}
+
+func (collector *ConntrackCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// Without the nf_conntrack module nothing is tracked.
+	if _, err := os.Stat("/proc/sys/net/netfilter/nf_conntrack_count"); os.IsNotExist(err) {
+		return nil
+	}
+
+	conntrack, err := p.ParseConntrack()
+	if err != nil {
+		return fmt.Errorf("parse conntrack metric failed, error: %s", err.Error())
+	}
+	ch <- prometheus.MustNewConstMetric(conntrackEntriesDesc, prometheus.GaugeValue, conntrack.Entries)
+	ch <- prometheus.MustNewConstMetric(conntrackEntriesLimitDesc, prometheus.GaugeValue, conntrack.EntriesLimit)
+
+	if _, err := os.Stat("/proc/net/stat/nf_conntrack"); os.IsNotExist(err) {
+		return nil
+	}
+
+	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/stat/nf_conntrack")
+	if err != nil {
+		return fmt.Errorf("get conntrack stat metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseConntrackStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse conntrack stat metric failed, error: %s", err.Error())
+	}
+
+	for _, stat := range stats {
+		for _, field := range conntrackStatFields {
+			// Older kernels lack some of the fields, e.g. search_restart.
+			value, ok := stat.Values[field.name]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(field.desc, prometheus.CounterValue, value, stat.CPU)
+		}
+	}
+
+	return nil
+}
+
+func newConntrackStatDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nf_conntrack", "stat_"+name+"_total"),
+		help,
+		[]string{"cpu"}, nil,
+	)
+}
+
+var (
+	conntrackEntriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nf_conntrack", "entries"),
+		"Number of currently allocated flow entries for connection tracking.",
+		nil, nil,
+	)
+	conntrackEntriesLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nf_conntrack", "entries_limit"),
+		"Maximum size of connection tracking table.",
+		nil, nil,
+	)
+
+	conntrackStatFields = []struct {
+		name string
+		desc *prometheus.Desc
+	}{
+		{"found", newConntrackStatDesc("found", "Number of searched entries which were successful.")},
+		{"invalid", newConntrackStatDesc("invalid", "Number of packets seen which can not be tracked.")},
+		{"ignore", newConntrackStatDesc("ignore", "Number of packets seen which are already connected to a conntrack entry.")},
+		{"insert_failed", newConntrackStatDesc("insert_failed", "Number of entries for which list insertion was attempted but failed.")},
+		{"drop", newConntrackStatDesc("drop", "Number of packets dropped due to conntrack failure.")},
+		{"early_drop", newConntrackStatDesc("early_drop", "Number of dropped conntrack entries to make room for new ones, if maximum table size was reached.")},
+		{"search_restart", newConntrackStatDesc("search_restart", "Number of conntrack table lookups which had to be restarted due to hashtable resizes.")},
+	}
+)
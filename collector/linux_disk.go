@@ -4,10 +4,8 @@ import (
 	"context"
 	"exporter/parser"
 	"exporter/util"
+	"flag"
 	"fmt"
-	"log"
-	"regexp"
-	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -38,6 +36,9 @@ func (collector *DiskCollector) Describe(ch chan<- *prometheus.Desc) error {
 	ch <- discardedSectorsDesc
 	ch <- diskcardTimeSecondsDesc
 	ch <- ioTimeWeightedSecondsDesc
+	ch <- flushRequestsDesc
+	ch <- flushRequestsTimeSecondsDesc
+	ch <- ignoredDevicesDesc
 	return nil
 }
 
@@ -55,60 +56,70 @@ func (collector *DiskCollector) Collect(p parser.Parser, ch chan<- prometheus.Me
 		return fmt.Errorf("host parse disk metric failed, error: %s", err.Error())
 	}
 
+	var ignoredDevices float64
 	for dev, stats := range stat {
-		if ignoredDevicesPattern.MatchString(dev) {
-			log.Println(fmt.Sprintf("[INFO] disk ignoring device %s", dev))
+		ignored, err := diskDeviceFilter.ignored(dev)
+		if err != nil {
+			return fmt.Errorf("disk device filter: %s", err.Error())
+		}
+		if ignored {
+			ignoredDevices++
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(readsCompletedDesc, prometheus.CounterValue, stats.ReadsCompleted, dev)
+		ch <- prometheus.MustNewConstMetric(readBytesDesc, prometheus.CounterValue, stats.ReadBytes, dev)
+		ch <- prometheus.MustNewConstMetric(writesCompletedDesc, prometheus.CounterValue, stats.WritesCompleted, dev)
+		ch <- prometheus.MustNewConstMetric(writtenBytesDesc, prometheus.CounterValue, stats.WrittenBytes, dev)
+		if stats.Fields < 11 {
 			continue
 		}
 
-		for index, valueStr := range stats {
-			value, err := strconv.ParseFloat(valueStr, 64)
-			if err != nil {
-				return fmt.Errorf(fmt.Sprintf("[INFO] disk parse device %s failed, error: %s", dev, err.Error()))
-			}
-			switch index {
-			case 0:
-				ch <- prometheus.MustNewConstMetric(readsCompletedDesc, prometheus.CounterValue, value, dev)
-			case 1:
-				ch <- prometheus.MustNewConstMetric(readsMergedDesc, prometheus.CounterValue, value, dev)
-			case 2:
-				ch <- prometheus.MustNewConstMetric(readBytesDesc, prometheus.CounterValue, value, dev)
-			case 3:
-				ch <- prometheus.MustNewConstMetric(readTimeSecondsDesc, prometheus.CounterValue, value, dev)
-			case 4:
-				ch <- prometheus.MustNewConstMetric(writesCompletedDesc, prometheus.CounterValue, value, dev)
-			case 5:
-				ch <- prometheus.MustNewConstMetric(writesMergedDesc, prometheus.CounterValue, value, dev)
-			case 6:
-				ch <- prometheus.MustNewConstMetric(writtenBytesDesc, prometheus.CounterValue, value, dev)
-			case 7:
-				ch <- prometheus.MustNewConstMetric(writeTimeSecondsDesc, prometheus.CounterValue, value, dev)
-			case 8:
-				ch <- prometheus.MustNewConstMetric(ioNowDesc, prometheus.CounterValue, value, dev)
-			case 9:
-				ch <- prometheus.MustNewConstMetric(ioTimeSecondsDesc, prometheus.CounterValue, value, dev)
-			case 10:
-				ch <- prometheus.MustNewConstMetric(ioTimeWeightedSecondsDesc, prometheus.CounterValue, value, dev)
-			case 11:
-				ch <- prometheus.MustNewConstMetric(discardsCompletedDesc, prometheus.CounterValue, value, dev)
-			case 12:
-				ch <- prometheus.MustNewConstMetric(discardsMergedDesc, prometheus.CounterValue, value, dev)
-			case 13:
-				ch <- prometheus.MustNewConstMetric(discardedSectorsDesc, prometheus.CounterValue, value, dev)
-			case 14:
-				ch <- prometheus.MustNewConstMetric(diskcardTimeSecondsDesc, prometheus.CounterValue, value, dev)
-			}
+		ch <- prometheus.MustNewConstMetric(readsMergedDesc, prometheus.CounterValue, stats.ReadsMerged, dev)
+		ch <- prometheus.MustNewConstMetric(readTimeSecondsDesc, prometheus.CounterValue, stats.ReadTimeSeconds, dev)
+		ch <- prometheus.MustNewConstMetric(writesMergedDesc, prometheus.CounterValue, stats.WritesMerged, dev)
+		ch <- prometheus.MustNewConstMetric(writeTimeSecondsDesc, prometheus.CounterValue, stats.WriteTimeSeconds, dev)
+		ch <- prometheus.MustNewConstMetric(ioNowDesc, prometheus.GaugeValue, stats.IONow, dev)
+		ch <- prometheus.MustNewConstMetric(ioTimeSecondsDesc, prometheus.CounterValue, stats.IOTimeSeconds, dev)
+		ch <- prometheus.MustNewConstMetric(ioTimeWeightedSecondsDesc, prometheus.CounterValue, stats.IOTimeWeightedSeconds, dev)
+
+		if stats.Fields >= 15 {
+			ch <- prometheus.MustNewConstMetric(discardsCompletedDesc, prometheus.CounterValue, stats.DiscardsCompleted, dev)
+			ch <- prometheus.MustNewConstMetric(discardsMergedDesc, prometheus.CounterValue, stats.DiscardsMerged, dev)
+			ch <- prometheus.MustNewConstMetric(discardedSectorsDesc, prometheus.CounterValue, stats.DiscardedSectors, dev)
+			ch <- prometheus.MustNewConstMetric(diskcardTimeSecondsDesc, prometheus.CounterValue, stats.DiscardTimeSeconds, dev)
+		}
+
+		if stats.Fields >= 17 {
+			ch <- prometheus.MustNewConstMetric(flushRequestsDesc, prometheus.CounterValue, stats.FlushRequests, dev)
+			ch <- prometheus.MustNewConstMetric(flushRequestsTimeSecondsDesc, prometheus.CounterValue, stats.FlushRequestsTimeSeconds, dev)
 		}
 	}
+	ch <- prometheus.MustNewConstMetric(ignoredDevicesDesc, prometheus.GaugeValue, ignoredDevices)
 
 	return nil
 }
 
 var (
+	diskDeviceInclude = flag.String(
+		"collector.disk.device-include",
+		"",
+		"Regexp of disk devices to include, all devices when empty.",
+	)
+	diskDeviceExclude = flag.String(
+		"collector.disk.device-exclude",
+		"^(ram|loop|fd)\\d+$",
+		"Regexp of disk devices to exclude, applied after device-include.",
+	)
+	diskDeviceFilter = newNameFilter(diskDeviceInclude, diskDeviceExclude)
+
 	diskLabelNames = []string{"device"}
-	ignore         = "^(ram|loop|fd|(h|s|v|xv)d[a-z]|nvme\\d+n\\d+p)\\d+$"
 
-	ignoredDevicesPattern = regexp.MustCompile(ignore)
+	ignoredDevicesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "ignored_devices"),
+		"The number of devices skipped by the device filter.",
+		nil, nil,
+	)
 
 	readsCompletedDesc = prometheus.NewDesc(
 		prometheus.BuildFQName("node", "disk", "reads_completed_total"),
@@ -201,19 +212,19 @@ var (
 		nil,
 	)
 
-	// flushRequestsDesc = prometheus.NewDesc(
-	// 	prometheus.BuildFQName("node", "disk", "flush_requests_total"),
-	// 	"The total number of flush requests completed successfully",
-	// 	diskLabelNames,
-	// 	nil,
-	// )
+	flushRequestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "flush_requests_total"),
+		"The total number of flush requests completed successfully",
+		diskLabelNames,
+		nil,
+	)
 
-	// flushRequestsTimeSecondsDesc = prometheus.NewDesc(
-	// 	prometheus.BuildFQName("node", "disk", "flush_requests_time_seconds_total"),
-	// 	"This is the total number of seconds spent by all flush requests.",
-	// 	diskLabelNames,
-	// 	nil,
-	// )
+	flushRequestsTimeSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "flush_requests_time_seconds_total"),
+		"This is the total number of seconds spent by all flush requests.",
+		diskLabelNames,
+		nil,
+	)
 
 	ioTimeWeightedSecondsDesc = prometheus.NewDesc(
 		prometheus.BuildFQName("node", "disk", "io_time_weighted_seconds_total"),
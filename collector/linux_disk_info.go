@@ -0,0 +1,119 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(DiskInfoCollector))
+}
+
+type DiskInfoCollector struct{}
+
+func (collector *DiskInfoCollector) GetName() string {
+	return "disk_info"
+}
+
+func (collector *DiskInfoCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- diskInfoDesc
+	ch <- diskSchedulerDesc
+	ch <- diskSizeDesc
+	ch <- diskLogicalBlockSizeDesc
+	ch <- diskPhysicalBlockSizeDesc
+	ch <- diskNrRequestsDesc
+	ch <- diskQueueDepthDesc
+	return nil
+}
+
+func (collector *DiskInfoCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	devices, err := p.ParseBlockDevices()
+	if err != nil {
+		return fmt.Errorf("parse block devices failed, error: %s", err.Error())
+	}
+
+	for _, device := range devices {
+		ignored, err := diskDeviceFilter.ignored(device.Name)
+		if err != nil {
+			return fmt.Errorf("disk device filter: %s", err.Error())
+		}
+		if ignored {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(diskInfoDesc, prometheus.GaugeValue, 1,
+			device.Name, device.Model, device.Serial, device.WWN, device.Revision,
+			device.Rotational, device.DMName, strings.Join(device.MDArrays, ","),
+		)
+		if device.Scheduler != "" {
+			ch <- prometheus.MustNewConstMetric(diskSchedulerDesc, prometheus.GaugeValue, 1, device.Name, device.Scheduler)
+		}
+
+		gauges := []struct {
+			desc  *prometheus.Desc
+			value *float64
+		}{
+			{diskSizeDesc, device.Size},
+			{diskLogicalBlockSizeDesc, device.LogicalBlockSize},
+			{diskPhysicalBlockSizeDesc, device.PhysicalBlockSize},
+			{diskNrRequestsDesc, device.NrRequests},
+			{diskQueueDepthDesc, device.QueueDepth},
+		}
+		for _, gauge := range gauges {
+			if gauge.value != nil {
+				ch <- prometheus.MustNewConstMetric(gauge.desc, prometheus.GaugeValue, *gauge.value, device.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	diskInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "info"),
+		"Info of /sys/block/<block_device>, value is always 1.",
+		[]string{"device", "model", "serial", "wwn", "revision", "rotational", "dm_name", "md_arrays"},
+		nil,
+	)
+
+	diskSchedulerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "scheduler_info"),
+		"The active I/O scheduler of the device, value is always 1.",
+		[]string{"device", "scheduler"},
+		nil,
+	)
+
+	diskSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "size_bytes"),
+		"The size of the device in bytes.",
+		diskLabelNames, nil,
+	)
+
+	diskLogicalBlockSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "logical_block_size_bytes"),
+		"The smallest unit the device can address, in bytes.",
+		diskLabelNames, nil,
+	)
+
+	diskPhysicalBlockSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "physical_block_size_bytes"),
+		"The smallest unit the device can write without read-modify-write, in bytes.",
+		diskLabelNames, nil,
+	)
+
+	diskNrRequestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "queue_nr_requests"),
+		"The number of requests the block layer may queue for the device.",
+		diskLabelNames, nil,
+	)
+
+	diskQueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "disk", "queue_depth"),
+		"The queue depth of the device as reported by its driver.",
+		diskLabelNames, nil,
+	)
+)
@@ -0,0 +1,142 @@
+package collector
+
+import (
+	"exporter/parser"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(EthtoolCollector))
+}
+
+type EthtoolCollector struct{}
+
+func (collector *EthtoolCollector) GetName() string {
+	return "ethtool"
+}
+
+func (collector *EthtoolCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- ethtoolInfoDesc
+	ch <- ethtoolSpeedDesc
+	ch <- ethtoolAutonegDesc
+	ch <- ethtoolSupportedAutonegDesc
+	ch <- ethtoolSupportedLinkModeDesc
+	return nil
+}
+
+func (collector *EthtoolCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	pattern, err := ethtoolStatsPattern.regexp()
+	if err != nil {
+		return fmt.Errorf("ethtool stats: %s", err.Error())
+	}
+
+	filter, err := netDevFilter()
+	if err != nil {
+		return fmt.Errorf("network device filter: %s", err.Error())
+	}
+
+	stats, err := p.ParseEthtool(filter)
+	if err != nil {
+		return fmt.Errorf("parse ethtool metric failed, error: %s", err.Error())
+	}
+
+	metricDescs := map[string]*prometheus.Desc{}
+	for _, stat := range stats {
+		if info := stat.DriverInfo; info != nil {
+			ch <- prometheus.MustNewConstMetric(ethtoolInfoDesc, prometheus.GaugeValue, 1,
+				stat.Device, info.Driver, info.Version, info.FirmwareVersion, info.BusInfo)
+		}
+
+		// Different driver names may sanitize to the same metric name, the
+		// first one is kept.
+		seen := make(map[string]bool, len(stat.Stats))
+		for key, value := range stat.Stats {
+			name := ethtoolMetricName(key)
+			if name == "" || seen[name] || !pattern.MatchString(key) {
+				continue
+			}
+			seen[name] = true
+
+			desc, ok := metricDescs[name]
+			if !ok {
+				desc = prometheus.NewDesc(
+					prometheus.BuildFQName("node", "ethtool", name),
+					fmt.Sprintf("Network interface driver statistic %s.", key),
+					[]string{"device"}, nil,
+				)
+				metricDescs[name] = desc
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.UntypedValue, float64(value), stat.Device)
+		}
+
+		if link := stat.Link; link != nil {
+			if link.Speed != nil {
+				ch <- prometheus.MustNewConstMetric(ethtoolSpeedDesc, prometheus.GaugeValue, *link.Speed, stat.Device)
+			}
+			ch <- prometheus.MustNewConstMetric(ethtoolAutonegDesc, prometheus.GaugeValue, boolValue(link.Autoneg), stat.Device)
+			ch <- prometheus.MustNewConstMetric(ethtoolSupportedAutonegDesc, prometheus.GaugeValue,
+				boolValue(link.SupportedAutoneg), stat.Device)
+			for _, mode := range link.SupportedModes {
+				ch <- prometheus.MustNewConstMetric(ethtoolSupportedLinkModeDesc, prometheus.GaugeValue, 1, stat.Device, mode)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ethtoolMetricName turns a driver statistic such as "rx-0.packets" into a
+// valid metric name.
+func ethtoolMetricName(key string) string {
+	name := ethtoolInvalidChars.ReplaceAllString(strings.ToLower(key), "_")
+	return strings.Trim(name, "_")
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+var (
+	ethtoolStats = flag.String(
+		"collector.ethtool.stats",
+		".*",
+		"Regexp of driver statistics to return for ethtool collector.",
+	)
+	ethtoolStatsPattern = newFlagRegexp(ethtoolStats)
+
+	ethtoolInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+	ethtoolInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "ethtool", "info"),
+		"Driver information of the network device, value is always 1.",
+		[]string{"device", "driver", "version", "firmware_version", "bus_info"}, nil,
+	)
+	ethtoolSpeedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "ethtool", "speed_bytes"),
+		"Negotiated link speed of the network device in bytes per second.",
+		[]string{"device"}, nil,
+	)
+	ethtoolAutonegDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "ethtool", "autonegotiate"),
+		"Value is 1 if link autonegotiation is enabled, 0 otherwise.",
+		[]string{"device"}, nil,
+	)
+	ethtoolSupportedAutonegDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "ethtool", "supported_autonegotiate"),
+		"Value is 1 if the network device supports link autonegotiation, 0 otherwise.",
+		[]string{"device"}, nil,
+	)
+	ethtoolSupportedLinkModeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "ethtool", "supported_link_mode"),
+		"Speed and duplex modes supported by the network device, value is always 1.",
+		[]string{"device", "mode"}, nil,
+	)
+)
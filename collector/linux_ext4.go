@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(Ext4Collector))
+}
+
+type Ext4Collector struct{}
+
+func (collector *Ext4Collector) GetName() string {
+	return "ext4"
+}
+
+func (collector *Ext4Collector) Describe(ch chan<- *prometheus.Desc) error {
+	for _, field := range ext4Fields {
+		ch <- field.desc
+	}
+	return nil
+}
+
+func (collector *Ext4Collector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	stats, err := p.ParseExt4Stats()
+	if err != nil {
+		return fmt.Errorf("parse ext4 metric failed, error: %s", err.Error())
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	mountPoints, err := mountPointsByBlockDevice(p)
+	if err != nil {
+		return fmt.Errorf("parse mountinfo failed, error: %s", err.Error())
+	}
+
+	for _, stat := range stats {
+		for key, value := range stat.Values {
+			field, ok := ext4Fields[key]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(field.desc, field.valueType, value, stat.Device, mountPoints[stat.Device])
+		}
+	}
+
+	return nil
+}
+
+var (
+	ext4LabelNames = []string{"device", "mountpoint"}
+
+	ext4Fields = map[string]struct {
+		desc      *prometheus.Desc
+		valueType prometheus.ValueType
+	}{
+		"errors_count": {
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "ext4", "errors_total"),
+				"Number of filesystem errors since the filesystem was created.",
+				ext4LabelNames, nil,
+			),
+			prometheus.CounterValue,
+		},
+		"warning_count": {
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "ext4", "warnings_total"),
+				"Number of filesystem warnings since the filesystem was mounted.",
+				ext4LabelNames, nil,
+			),
+			prometheus.CounterValue,
+		},
+		"msg_count": {
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "ext4", "messages_total"),
+				"Number of filesystem messages since the filesystem was mounted.",
+				ext4LabelNames, nil,
+			),
+			prometheus.CounterValue,
+		},
+		"first_error_time": {
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "ext4", "first_error_time_seconds"),
+				"Unix time of the first filesystem error, 0 if there was none.",
+				ext4LabelNames, nil,
+			),
+			prometheus.GaugeValue,
+		},
+		"last_error_time": {
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "ext4", "last_error_time_seconds"),
+				"Unix time of the last filesystem error, 0 if there was none.",
+				ext4LabelNames, nil,
+			),
+			prometheus.GaugeValue,
+		},
+	}
+)
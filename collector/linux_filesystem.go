@@ -2,12 +2,17 @@ package collector
 
 import (
 	"context"
-	"errors"
 	"exporter/parser"
 	"exporter/util"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -30,6 +35,11 @@ func (collector *FileSystemCollector) Describe(ch chan<- *prometheus.Desc) error
 	ch <- filesFreeDesc
 	ch <- roDesc
 	ch <- deviceErrorDesc
+	ch <- mountInfoDesc
+	if *fileSystemRatios {
+		ch <- availRatioDesc
+		ch <- filesFreeRatioDesc
+	}
 
 	return nil
 }
@@ -40,11 +50,21 @@ func (collector *FileSystemCollector) Collect(p parser.Parser, ch chan<- prometh
 		return fmt.Errorf("[ERROR] get mountpoint details failed, error: %s", err.Error())
 	}
 
-	stats, err := p.ParseFileSystemStat(bytes)
+	options, err := fileSystemOptions()
+	if err != nil {
+		return fmt.Errorf("[ERROR] filesystem options: %s", err.Error())
+	}
+
+	stats, err := p.ParseFileSystemStat(bytes, options)
 	if err != nil {
 		return fmt.Errorf("[ERROR] parse filesystem metric failed, error: %s", err.Error())
 	}
 
+	mounts, err := mountInfoByMountPoint(p)
+	if err != nil {
+		return fmt.Errorf("[ERROR] parse mountinfo failed, error: %s", err.Error())
+	}
+
 	// Make sure we expose a metric once, even if there are multiple mounts
 	seen := map[parser.FileSystemLabels]bool{}
 	for _, s := range stats {
@@ -53,6 +73,12 @@ func (collector *FileSystemCollector) Collect(p parser.Parser, ch chan<- prometh
 		}
 		seen[s.Labels] = true
 
+		mount := mounts[s.Labels.MountPoint]
+		ch <- prometheus.MustNewConstMetric(
+			mountInfoDesc, prometheus.GaugeValue,
+			1, s.Labels.Device, s.Labels.MountPoint, s.Labels.FsType, s.Labels.Options,
+			mount.MountID, mount.ParentID, mount.Propagation, mount.MajorMinor, mount.BlockDevice,
+		)
 		ch <- prometheus.MustNewConstMetric(
 			deviceErrorDesc, prometheus.GaugeValue,
 			s.DeviceError, s.Labels.Device, s.Labels.MountPoint, s.Labels.FsType,
@@ -85,27 +111,179 @@ func (collector *FileSystemCollector) Collect(p parser.Parser, ch chan<- prometh
 			roDesc, prometheus.GaugeValue,
 			s.Ro, s.Labels.Device, s.Labels.MountPoint, s.Labels.FsType,
 		)
+
+		if !*fileSystemRatios {
+			continue
+		}
+		if s.Size > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				availRatioDesc, prometheus.GaugeValue,
+				s.Avail/s.Size, s.Labels.Device, s.Labels.MountPoint, s.Labels.FsType,
+			)
+		}
+		if s.Files > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				filesFreeRatioDesc, prometheus.GaugeValue,
+				s.FilesFree/s.Files, s.Labels.Device, s.Labels.MountPoint, s.Labels.FsType,
+			)
+		}
 	}
 	return nil
 }
 
-func mountpointDetails() ([]byte, error) {
-	var (
-		path         = "cat"
-		args         = "/proc/1/mounts"
-		fallbackArgs = "/proc/mounts"
-	)
+func fileSystemOptions() (parser.FileSystemOptions, error) {
+	options := parser.FileSystemOptions{
+		MountTimeout: *fileSystemMountTimeout,
+		StatWorkers:  *fileSystemStatWorkers,
+	}
 
-	bytes, err := util.ExecCommand(context.Background(), path, args)
-	if errors.Is(err, os.ErrNotExist) {
-		log.Println("Reading root mounts failed, falling back to system mounts" + err.Error())
-		bytes, err = util.ExecCommand(context.Background(), path, fallbackArgs)
+	var err error
+	options.MountPointsInclude, options.MountPointsExclude, err = fileSystemMountPointsFilter.patterns()
+	if err != nil {
+		return options, err
 	}
+	options.FSTypesInclude, options.FSTypesExclude, err = fileSystemFSTypesFilter.patterns()
+	return options, err
+}
 
-	return bytes, err
+// fileSystemMounts returns the mounts the filesystem collector reports on,
+// selected by its mount point and filesystem type flags.
+func fileSystemMounts(p parser.Parser) ([]parser.FileSystemLabels, error) {
+	bytes, err := mountpointDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := fileSystemOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ParseFileSystemMounts(bytes, options)
+}
+
+// mountInfoByMountPoint indexes the mountinfo of mountProcDir by mount point. For
+// stacked mounts the last one, which is the visible one, wins.
+func mountInfoByMountPoint(p parser.Parser) (map[string]parser.MountInfo, error) {
+	bytes, err := util.ExecCommand(context.Background(), "cat", filepath.Join(mountProcDir(), "mountinfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	mounts, err := p.ParseMountInfo(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	byMountPoint := make(map[string]parser.MountInfo, len(mounts))
+	for _, mount := range mounts {
+		byMountPoint[mount.MountPoint] = mount
+	}
+	return byMountPoint, nil
+}
+
+// mountPointsByBlockDevice maps block device names such as "sda1" or "dm-0"
+// to the mount point of the filesystem they hold, so filesystem specific
+// metrics can carry the same mountpoint label as the filesystem collector.
+func mountPointsByBlockDevice(p parser.Parser) (map[string]string, error) {
+	mounts, err := mountInfoByMountPoint(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Visit mount points in order so a filesystem mounted more than once
+	// always reports the same one.
+	names := make([]string, 0, len(mounts))
+	for mountPoint := range mounts {
+		names = append(names, mountPoint)
+	}
+	sort.Strings(names)
+
+	mountPoints := map[string]string{}
+	for _, mountPoint := range names {
+		mount := mounts[mountPoint]
+		// Bind mounts of a subdirectory share the device, keep the mount of
+		// the filesystem root.
+		if mount.Root != "/" {
+			continue
+		}
+		devices := []string{mount.BlockDevice}
+		// btrfs reports an anonymous device number, its source names the disk.
+		if strings.HasPrefix(mount.Source, "/dev/") {
+			devices = append(devices, filepath.Base(mount.Source))
+		}
+		for _, device := range devices {
+			if _, ok := mountPoints[device]; device != "" && !ok {
+				mountPoints[device] = mountPoint
+			}
+		}
+	}
+	return mountPoints, nil
+}
+
+func mountpointDetails() ([]byte, error) {
+	return util.ExecCommand(context.Background(), "cat", filepath.Join(mountProcDir(), "mounts"))
+}
+
+// mountProcDir returns the /proc directory of the process whose mounts are
+// reported: pid 1, or this process when pid 1 is not readable, e.g. when not
+// running as root. The mount list and mountinfo are both read from it, as
+// they only join up within the same mount namespace.
+func mountProcDir() string {
+	f, err := os.Open("/proc/1/mountinfo")
+	if err != nil {
+		mountProcFallbackOnce.Do(func() {
+			log.Println(fmt.Sprintf("[WARN] reading root mounts failed, falling back to own mounts, error: %s", err.Error()))
+		})
+		return "/proc/self"
+	}
+	f.Close()
+	return "/proc/1"
 }
 
 var (
+	mountProcFallbackOnce sync.Once
+
+	fileSystemMountPointsInclude = flag.String(
+		"collector.filesystem.mount-points-include",
+		"",
+		"Regexp of mount points to include for filesystem collector, all when empty.",
+	)
+	fileSystemMountPointsExclude = flag.String(
+		"collector.filesystem.mount-points-exclude",
+		"^/(dev|proc|sys|var/lib/docker/.+)($|/)",
+		"Regexp of mount points to exclude for filesystem collector.",
+	)
+	fileSystemMountPointsFilter = newNameFilter(fileSystemMountPointsInclude, fileSystemMountPointsExclude)
+
+	fileSystemFSTypesInclude = flag.String(
+		"collector.filesystem.fs-types-include",
+		"",
+		"Regexp of filesystem types to include for filesystem collector, all when empty.",
+	)
+	fileSystemFSTypesExclude = flag.String(
+		"collector.filesystem.fs-types-exclude",
+		"^(autofs|binfmt_misc|bpf|cgroup2?|configfs|debugfs|devpts|devtmpfs|fusectl|hugetlbfs|iso9660|mqueue|nsfs|overlay|proc|procfs|pstore|rpc_pipefs|securityfs|selinuxfs|squashfs|sysfs|tracefs)$",
+		"Regexp of filesystem types to exclude for filesystem collector.",
+	)
+	fileSystemFSTypesFilter = newNameFilter(fileSystemFSTypesInclude, fileSystemFSTypesExclude)
+
+	fileSystemMountTimeout = flag.Duration(
+		"collector.filesystem.mount-timeout",
+		5*time.Second,
+		"How long to wait for a mount to respond before marking it as stale.",
+	)
+	fileSystemRatios = flag.Bool(
+		"collector.filesystem.ratios",
+		false,
+		"Export the avail and files free ratios of each filesystem.",
+	)
+	fileSystemStatWorkers = flag.Int(
+		"collector.filesystem.stat-workers",
+		4,
+		"Maximum number of concurrent statfs calls.",
+	)
+
 	filesystemLabelNames = []string{"device", "mountpoint", "fstype"}
 	sizeDesc             = prometheus.NewDesc(
 		prometheus.BuildFQName("node", "filesystem", "size_bytes"),
@@ -143,6 +321,24 @@ var (
 		filesystemLabelNames, nil,
 	)
 
+	mountInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "filesystem", "mount_info"),
+		"Filesystem mount information from mountinfo, value is always 1.",
+		[]string{"device", "mountpoint", "fstype", "options", "mount_id", "parent_id", "propagation", "major_minor", "block_device"}, nil,
+	)
+
+	availRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "filesystem", "avail_ratio"),
+		"Ratio of filesystem space available to non-root users.",
+		filesystemLabelNames, nil,
+	)
+
+	filesFreeRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "filesystem", "files_free_ratio"),
+		"Ratio of free file nodes of the filesystem.",
+		filesystemLabelNames, nil,
+	)
+
 	deviceErrorDesc = prometheus.NewDesc(
 		prometheus.BuildFQName("node", "filesystem", "device_error"),
 		"Whether an error occurred while getting statistics for the given device.",
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"flag"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(InterruptsCollector))
+}
+
+type InterruptsCollector struct{}
+
+func (collector *InterruptsCollector) GetName() string {
+	return "interrupts"
+}
+
+func (collector *InterruptsCollector) Describe(ch chan<- *prometheus.Desc) error {
+	if *interruptsAggregate {
+		ch <- interruptsAggregateDesc
+	} else {
+		ch <- interruptsDesc
+	}
+	ch <- interruptsSummaryDesc
+	return nil
+}
+
+func (collector *InterruptsCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	path := "cat"
+	args := []string{"/proc/interrupts"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get interrupts metric failed, error: %s", err.Error())
+	}
+
+	stat, err := p.ParseInterrupts(bytes)
+	if err != nil {
+		return fmt.Errorf("parse interrupts metric failed, error: %s", err.Error())
+	}
+
+	for irq, interrupt := range stat {
+		// Summary lines count system wide, they have no CPU to report.
+		if interrupt.Summary {
+			ch <- prometheus.MustNewConstMetric(interruptsSummaryDesc, prometheus.CounterValue, float64(interrupt.Total), irq)
+			continue
+		}
+
+		if *interruptsAggregate {
+			var total uint64
+			for _, value := range interrupt.Values {
+				total += value
+			}
+			ch <- prometheus.MustNewConstMetric(interruptsAggregateDesc, prometheus.CounterValue, float64(total),
+				irq, interrupt.Type, interrupt.Devices)
+			continue
+		}
+
+		for cpu, value := range interrupt.Values {
+			ch <- prometheus.MustNewConstMetric(interruptsDesc, prometheus.CounterValue, float64(value),
+				cpu, irq, interrupt.Type, interrupt.Devices)
+		}
+	}
+
+	return nil
+}
+
+var (
+	interruptsAggregate = flag.Bool(
+		"collector.interrupts.aggregate",
+		false,
+		"Sum interrupts over all CPUs instead of exporting a series per CPU.",
+	)
+
+	interruptsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "interrupts", "total"),
+		"Number of interrupts handled per CPU and IRQ.",
+		[]string{"cpu", "irq", "type", "devices"}, nil,
+	)
+
+	interruptsAggregateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "interrupts", "total"),
+		"Number of interrupts handled per IRQ, summed over all CPUs.",
+		[]string{"irq", "type", "devices"}, nil,
+	)
+
+	interruptsSummaryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "interrupts", "summary_total"),
+		"System wide interrupt counts of /proc/interrupts without a per-CPU breakdown, e.g. ERR and MIS.",
+		[]string{"irq"}, nil,
+	)
+)
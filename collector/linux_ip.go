@@ -2,6 +2,7 @@ package collector
 
 import (
 	"exporter/parser"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -30,7 +31,12 @@ func (collector *IPCollector) Describe(ch chan<- *prometheus.Desc) error {
 }
 
 func (collector *IPCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
-	ipStat, err := p.ParseIPStat()
+	filter, err := netDevFilter()
+	if err != nil {
+		return fmt.Errorf("network device filter: %s", err.Error())
+	}
+
+	ipStat, err := p.ParseIPStat(filter)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,162 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(MDAdmCollector))
+}
+
+type MDAdmCollector struct{}
+
+func (collector *MDAdmCollector) GetName() string {
+	return "mdadm"
+}
+
+func (collector *MDAdmCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- mdInfoDesc
+	ch <- mdStateDesc
+	ch <- mdDisksDesc
+	ch <- mdDisksRequiredDesc
+	ch <- mdBlocksDesc
+	ch <- mdBlocksSyncedDesc
+	ch <- mdSyncCompletionDesc
+	ch <- mdDegradedDesc
+	ch <- mdMismatchDesc
+	return nil
+}
+
+func (collector *MDAdmCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// Without the md driver there is no mdstat and nothing to report.
+	if _, err := os.Stat("/proc/mdstat"); os.IsNotExist(err) {
+		return nil
+	}
+
+	path := "cat"
+	args := []string{"/proc/mdstat"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get mdstat metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseMDStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse mdstat metric failed, error: %s", err.Error())
+	}
+
+	for _, stat := range stats {
+		array, err := p.ParseMDArray(stat.Name)
+		if err != nil {
+			return fmt.Errorf("parse md array %s failed, error: %s", stat.Name, err.Error())
+		}
+
+		// sysfs names the running operation directly, mdstat only has it in
+		// free text, so prefer the former.
+		state := stat.State
+		if syncState, ok := mdSyncActions[array.SyncAction]; ok {
+			state = syncState
+		}
+
+		ch <- prometheus.MustNewConstMetric(mdInfoDesc, prometheus.GaugeValue, 1, stat.Name, stat.Level, array.ArrayState)
+		for _, s := range mdStates {
+			var value float64
+			if s == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(mdStateDesc, prometheus.GaugeValue, value, stat.Name, s)
+		}
+
+		ch <- prometheus.MustNewConstMetric(mdDisksDesc, prometheus.GaugeValue, stat.DisksActive, stat.Name, "active")
+		ch <- prometheus.MustNewConstMetric(mdDisksDesc, prometheus.GaugeValue, stat.DisksFailed, stat.Name, "failed")
+		ch <- prometheus.MustNewConstMetric(mdDisksDesc, prometheus.GaugeValue, stat.DisksSpare, stat.Name, "spare")
+		ch <- prometheus.MustNewConstMetric(mdDisksRequiredDesc, prometheus.GaugeValue, stat.DisksTotal, stat.Name)
+		ch <- prometheus.MustNewConstMetric(mdBlocksDesc, prometheus.GaugeValue, stat.BlocksTotal, stat.Name)
+		ch <- prometheus.MustNewConstMetric(mdBlocksSyncedDesc, prometheus.GaugeValue, stat.BlocksSynced, stat.Name)
+		ch <- prometheus.MustNewConstMetric(mdSyncCompletionDesc, prometheus.GaugeValue, stat.SyncCompletion, stat.Name)
+
+		if array.Degraded != nil {
+			ch <- prometheus.MustNewConstMetric(mdDegradedDesc, prometheus.GaugeValue, *array.Degraded, stat.Name)
+		}
+		if array.MismatchCount != nil {
+			ch <- prometheus.MustNewConstMetric(mdMismatchDesc, prometheus.GaugeValue, *array.MismatchCount, stat.Name)
+		}
+	}
+
+	return nil
+}
+
+var (
+	mdStates = []string{"active", "inactive", "recovering", "resync", "check", "reshape"}
+	// sync_action values of /sys/block/<md>/md that name a running operation.
+	mdSyncActions = map[string]string{
+		"recover": "recovering",
+		"resync":  "resync",
+		"check":   "check",
+		"repair":  "check",
+		"reshape": "reshape",
+	}
+
+	mdLabelNames = []string{"device"}
+
+	mdInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "info"),
+		"Info of the md device, value is always 1.",
+		[]string{"device", "level", "array_state"}, nil,
+	)
+
+	mdStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "state"),
+		"Indicates the state of the md device.",
+		[]string{"device", "state"}, nil,
+	)
+
+	mdDisksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "disks"),
+		"Number of active, failed and spare disks of the md device.",
+		[]string{"device", "state"}, nil,
+	)
+
+	mdDisksRequiredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "disks_required"),
+		"Total number of disks the md device requires.",
+		mdLabelNames, nil,
+	)
+
+	mdBlocksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "blocks"),
+		"Total number of blocks of the md device.",
+		mdLabelNames, nil,
+	)
+
+	mdBlocksSyncedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "blocks_synced"),
+		"Number of blocks processed by the running sync operation, the total when in sync.",
+		mdLabelNames, nil,
+	)
+
+	mdSyncCompletionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "sync_completion_ratio"),
+		"Progress of the running sync operation, 1 when in sync.",
+		mdLabelNames, nil,
+	)
+
+	mdDegradedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "degraded"),
+		"Number of missing or failed devices of the md device.",
+		mdLabelNames, nil,
+	)
+
+	mdMismatchDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "md", "mismatch_sectors"),
+		"Number of sectors found inconsistent by the last check or repair.",
+		mdLabelNames, nil,
+	)
+)
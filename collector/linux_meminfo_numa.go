@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(MemInfoNUMACollector))
+}
+
+type MemInfoNUMACollector struct{}
+
+func (collector *MemInfoNUMACollector) GetName() string {
+	return "meminfo_numa"
+}
+
+func (collector *MemInfoNUMACollector) Describe(ch chan<- *prometheus.Desc) error {
+	for _, desc := range memInfoNUMADescs {
+		ch <- desc
+	}
+	for _, desc := range numaStatDescs {
+		ch <- desc
+	}
+	return nil
+}
+
+func (collector *MemInfoNUMACollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	stats, err := p.ParseNUMAMemoryStat()
+	if err != nil {
+		return fmt.Errorf("parse numa memory metric failed, error: %s", err.Error())
+	}
+
+	// Per-node figures only add value when there is more than one node.
+	if len(stats) < 2 {
+		return nil
+	}
+
+	for _, stat := range stats {
+		for key, value := range stat.MemInfo {
+			// Fields of newer kernels are skipped until they are added below.
+			if desc, ok := memInfoNUMADescs[key]; ok {
+				ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, stat.Node)
+			}
+		}
+
+		for key, value := range stat.NUMAStat {
+			if desc, ok := numaStatDescs[key]; ok {
+				ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value, stat.Node)
+			}
+		}
+	}
+
+	return nil
+}
+
+// newMemInfoNUMADescs returns a descriptor for each key, named after the key
+// and suffix.
+func newMemInfoNUMADescs(help, suffix string, keys ...string) map[string]*prometheus.Desc {
+	descs := make(map[string]*prometheus.Desc, len(keys))
+	for _, key := range keys {
+		descs[key] = prometheus.NewDesc(
+			prometheus.BuildFQName("node", "memory_numa", key+suffix),
+			fmt.Sprintf(help, key),
+			memInfoNUMALabelNames, nil,
+		)
+	}
+	return descs
+}
+
+var (
+	memInfoNUMALabelNames = []string{"node"}
+
+	// memInfoNUMADescs are the fields of /sys/devices/system/node/node<N>/meminfo,
+	// keyed as ParseNUMAMemoryStat reports them.
+	memInfoNUMADescs = newMemInfoNUMADescs("Memory information field %s.", "",
+		"MemTotal_bytes", "MemFree_bytes", "MemUsed_bytes", "SwapCached_bytes",
+		"Active_bytes", "Inactive_bytes", "Active_anon_bytes", "Inactive_anon_bytes",
+		"Active_file_bytes", "Inactive_file_bytes", "Unevictable_bytes", "Mlocked_bytes",
+		"Dirty_bytes", "Writeback_bytes", "FilePages_bytes", "Mapped_bytes",
+		"AnonPages_bytes", "Shmem_bytes", "KernelStack_bytes", "PageTables_bytes",
+		"SecPageTables_bytes", "NFS_Unstable_bytes", "Bounce_bytes", "WritebackTmp_bytes",
+		"KReclaimable_bytes", "Slab_bytes", "SReclaimable_bytes", "SUnreclaim_bytes",
+		"AnonHugePages_bytes", "ShmemHugePages_bytes", "ShmemPmdMapped_bytes",
+		"FileHugePages_bytes", "FilePmdMapped_bytes",
+		"HugePages_Total", "HugePages_Free", "HugePages_Surp",
+	)
+
+	// numaStatDescs are the allocation counters of /sys/devices/system/node/node<N>/numastat.
+	numaStatDescs = newMemInfoNUMADescs("NUMA allocation statistic %s.", "_total",
+		"numa_hit", "numa_miss", "numa_foreign", "interleave_hit", "local_node", "other_node",
+	)
+)
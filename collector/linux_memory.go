@@ -5,12 +5,19 @@ import (
 	"exporter/parser"
 	"exporter/util"
 	"fmt"
-	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
+	for key, field := range memoryFields {
+		memoryFieldDescs[key] = prometheus.NewDesc(
+			prometheus.BuildFQName("node", "memory", key),
+			field.help,
+			nil, nil,
+		)
+	}
+
 	registerCollector(new(MemoryCollector))
 }
 
@@ -21,6 +28,11 @@ func (collector *MemoryCollector) GetName() string {
 }
 
 func (collector *MemoryCollector) Describe(ch chan<- *prometheus.Desc) error {
+	for _, desc := range memoryFieldDescs {
+		ch <- desc
+	}
+	ch <- memoryUsedDesc
+	ch <- memoryAvailableRatioDesc
 	return nil
 }
 
@@ -38,22 +50,133 @@ func (collector *MemoryCollector) Collect(p parser.Parser, ch chan<- prometheus.
 		return fmt.Errorf("parse memory metric failed, error: %s", err.Error())
 	}
 
-	var metricType prometheus.ValueType
 	for key, value := range stat {
-		if strings.HasSuffix(key, "_total") {
-			metricType = prometheus.CounterValue
-		} else {
-			metricType = prometheus.GaugeValue
+		field, ok := memoryFields[key]
+		if !ok {
+			// Fields added by newer kernels are still exported, just without a proper help.
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("node", "memory", key),
+					fmt.Sprintf("Memory information field %s.", key),
+					nil, nil,
+				),
+				prometheus.GaugeValue, value,
+			)
+			continue
 		}
-		ch <- prometheus.MustNewConstMetric(
-			prometheus.NewDesc(
-				prometheus.BuildFQName("node", "memory", key),
-				fmt.Sprintf("Memory information field %s.", key),
-				nil, nil,
-			),
-			metricType, value,
-		)
+		ch <- prometheus.MustNewConstMetric(memoryFieldDescs[key], field.valueType, value)
 	}
 
+	total, ok := stat["MemTotal_bytes"]
+	if !ok || total == 0 {
+		return nil
+	}
+	available := memoryAvailable(stat)
+	ch <- prometheus.MustNewConstMetric(memoryUsedDesc, prometheus.GaugeValue, total-available)
+	ch <- prometheus.MustNewConstMetric(memoryAvailableRatioDesc, prometheus.GaugeValue, available/total)
+
 	return nil
 }
+
+// memoryAvailable follows free(1): MemAvailable when the kernel provides it
+// (3.14+), otherwise free memory plus buffers and reclaimable caches.
+func memoryAvailable(stat parser.MemoryStat) float64 {
+	if available, ok := stat["MemAvailable_bytes"]; ok {
+		return available
+	}
+	available := stat["MemFree_bytes"] + stat["Buffers_bytes"] + stat["Cached_bytes"] + stat["SReclaimable_bytes"]
+	if total := stat["MemTotal_bytes"]; available > total {
+		return total
+	}
+	return available
+}
+
+type memoryField struct {
+	help      string
+	valueType prometheus.ValueType
+}
+
+var (
+	// memoryFields lists the known /proc/meminfo fields, keyed as returned by ParseMemoryStat.
+	memoryFields = map[string]memoryField{
+		"MemTotal_bytes":          {"Total usable RAM in bytes.", prometheus.GaugeValue},
+		"MemFree_bytes":           {"Unused RAM in bytes.", prometheus.GaugeValue},
+		"MemAvailable_bytes":      {"Estimated memory available for starting new applications without swapping, in bytes.", prometheus.GaugeValue},
+		"Buffers_bytes":           {"Memory in raw disk block buffers, in bytes.", prometheus.GaugeValue},
+		"Cached_bytes":            {"Memory in the page cache, excluding swap cache, in bytes.", prometheus.GaugeValue},
+		"SwapCached_bytes":        {"Memory that was swapped out and is also still in the swap file, in bytes.", prometheus.GaugeValue},
+		"Active_bytes":            {"Memory used recently and usually not reclaimed, in bytes.", prometheus.GaugeValue},
+		"Inactive_bytes":          {"Memory used less recently and eligible for reclaim, in bytes.", prometheus.GaugeValue},
+		"Active_anon_bytes":       {"Active anonymous memory in bytes.", prometheus.GaugeValue},
+		"Inactive_anon_bytes":     {"Inactive anonymous memory in bytes.", prometheus.GaugeValue},
+		"Active_file_bytes":       {"Active file-backed memory in bytes.", prometheus.GaugeValue},
+		"Inactive_file_bytes":     {"Inactive file-backed memory in bytes.", prometheus.GaugeValue},
+		"Unevictable_bytes":       {"Memory that cannot be paged out, in bytes.", prometheus.GaugeValue},
+		"Mlocked_bytes":           {"Memory locked with mlock(), in bytes.", prometheus.GaugeValue},
+		"HighTotal_bytes":         {"Total highmem in bytes.", prometheus.GaugeValue},
+		"HighFree_bytes":          {"Free highmem in bytes.", prometheus.GaugeValue},
+		"LowTotal_bytes":          {"Total lowmem in bytes.", prometheus.GaugeValue},
+		"LowFree_bytes":           {"Free lowmem in bytes.", prometheus.GaugeValue},
+		"MmapCopy_bytes":          {"Memory used to copy private mappings on no-MMU systems, in bytes.", prometheus.GaugeValue},
+		"SwapTotal_bytes":         {"Total swap space in bytes.", prometheus.GaugeValue},
+		"SwapFree_bytes":          {"Unused swap space in bytes.", prometheus.GaugeValue},
+		"Zswap_bytes":             {"Memory consumed by the zswap backend, in bytes.", prometheus.GaugeValue},
+		"Zswapped_bytes":          {"Anonymous memory stored in zswap, in bytes.", prometheus.GaugeValue},
+		"Dirty_bytes":             {"Memory waiting to be written back to disk, in bytes.", prometheus.GaugeValue},
+		"Writeback_bytes":         {"Memory actively being written back to disk, in bytes.", prometheus.GaugeValue},
+		"AnonPages_bytes":         {"Non file-backed pages mapped into user page tables, in bytes.", prometheus.GaugeValue},
+		"Mapped_bytes":            {"Files mapped into memory, such as libraries, in bytes.", prometheus.GaugeValue},
+		"Shmem_bytes":             {"Memory used by shared memory and tmpfs, in bytes.", prometheus.GaugeValue},
+		"KReclaimable_bytes":      {"Kernel allocations the kernel will try to reclaim under memory pressure, in bytes.", prometheus.GaugeValue},
+		"Slab_bytes":              {"In-kernel data structures cache, in bytes.", prometheus.GaugeValue},
+		"SReclaimable_bytes":      {"Part of the slab that might be reclaimed, in bytes.", prometheus.GaugeValue},
+		"SUnreclaim_bytes":        {"Part of the slab that cannot be reclaimed, in bytes.", prometheus.GaugeValue},
+		"KernelStack_bytes":       {"Memory used by kernel stacks, in bytes.", prometheus.GaugeValue},
+		"ShadowCallStack_bytes":   {"Memory used by shadow call stacks, in bytes.", prometheus.GaugeValue},
+		"PageTables_bytes":        {"Memory used by the lowest level of page tables, in bytes.", prometheus.GaugeValue},
+		"SecPageTables_bytes":     {"Memory used by secondary page tables, in bytes.", prometheus.GaugeValue},
+		"NFS_Unstable_bytes":      {"NFS pages sent to the server but not yet committed to stable storage, in bytes.", prometheus.GaugeValue},
+		"Bounce_bytes":            {"Memory used for block device bounce buffers, in bytes.", prometheus.GaugeValue},
+		"WritebackTmp_bytes":      {"Memory used by FUSE for temporary writeback buffers, in bytes.", prometheus.GaugeValue},
+		"CommitLimit_bytes":       {"Total memory that can be allocated under the current overcommit policy, in bytes.", prometheus.GaugeValue},
+		"Committed_AS_bytes":      {"Memory currently allocated on the system, in bytes.", prometheus.GaugeValue},
+		"VmallocTotal_bytes":      {"Total size of the vmalloc address space, in bytes.", prometheus.GaugeValue},
+		"VmallocUsed_bytes":       {"Used vmalloc area in bytes.", prometheus.GaugeValue},
+		"VmallocChunk_bytes":      {"Largest contiguous free block of the vmalloc area, in bytes.", prometheus.GaugeValue},
+		"Percpu_bytes":            {"Memory allocated to the percpu allocator, in bytes.", prometheus.GaugeValue},
+		"HardwareCorrupted_bytes": {"Memory the kernel identified as corrupted, in bytes.", prometheus.GaugeValue},
+		"AnonHugePages_bytes":     {"Non file-backed huge pages mapped into user page tables, in bytes.", prometheus.GaugeValue},
+		"ShmemHugePages_bytes":    {"Memory used by shared memory and tmpfs allocated with huge pages, in bytes.", prometheus.GaugeValue},
+		"ShmemPmdMapped_bytes":    {"Shared memory mapped into user space with huge pages, in bytes.", prometheus.GaugeValue},
+		"FileHugePages_bytes":     {"Memory used for file cache allocated with huge pages, in bytes.", prometheus.GaugeValue},
+		"FilePmdMapped_bytes":     {"Page cache mapped into user space with huge pages, in bytes.", prometheus.GaugeValue},
+		"CmaTotal_bytes":          {"Memory reserved for the contiguous memory allocator, in bytes.", prometheus.GaugeValue},
+		"CmaFree_bytes":           {"Free contiguous memory allocator reserves, in bytes.", prometheus.GaugeValue},
+		"Unaccepted_bytes":        {"Memory not yet accepted by the guest, in bytes.", prometheus.GaugeValue},
+		"Balloon_bytes":           {"Memory returned to the host by the balloon driver, in bytes.", prometheus.GaugeValue},
+		"HugePages_Total":         {"Size of the huge page pool.", prometheus.GaugeValue},
+		"HugePages_Free":          {"Number of huge pages in the pool that are not yet allocated.", prometheus.GaugeValue},
+		"HugePages_Rsvd":          {"Number of huge pages reserved for allocation but not yet allocated.", prometheus.GaugeValue},
+		"HugePages_Surp":          {"Number of huge pages in the pool above the configured size.", prometheus.GaugeValue},
+		"Hugepagesize_bytes":      {"Default huge page size in bytes.", prometheus.GaugeValue},
+		"Hugetlb_bytes":           {"Memory consumed by huge pages of all sizes, in bytes.", prometheus.GaugeValue},
+		"DirectMap4k_bytes":       {"Kernel direct mapping backed by 4k pages, in bytes.", prometheus.GaugeValue},
+		"DirectMap2M_bytes":       {"Kernel direct mapping backed by 2M pages, in bytes.", prometheus.GaugeValue},
+		"DirectMap4M_bytes":       {"Kernel direct mapping backed by 4M pages, in bytes.", prometheus.GaugeValue},
+		"DirectMap1G_bytes":       {"Kernel direct mapping backed by 1G pages, in bytes.", prometheus.GaugeValue},
+	}
+
+	memoryFieldDescs = map[string]*prometheus.Desc{}
+
+	memoryUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "memory", "used_bytes"),
+		"Memory in use as reported by free(1): total minus available, in bytes.",
+		nil, nil,
+	)
+
+	memoryAvailableRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "memory", "available_ratio"),
+		"Ratio of available memory to total memory.",
+		nil, nil,
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"exporter/parser"
 	"exporter/util"
+	"flag"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,21 +21,49 @@ func (collector *NetCollector) GetName() string {
 }
 
 func (collector *NetCollector) Describe(ch chan<- *prometheus.Desc) error {
+	if *netDevSource == "netlink" {
+		ch <- netLinkInfoDesc
+		ch <- netLinkMTUDesc
+	}
 	return nil
 }
 
 func (collector *NetCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
-	path := "cat"
-	args := []string{"/proc/net/dev"}
-
-	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	filter, err := netDevFilter()
 	if err != nil {
-		return fmt.Errorf("exec netdev command failed, error: %s", err.Error())
+		return fmt.Errorf("network device filter: %s", err.Error())
 	}
 
-	stat, err := p.ParseNetStat(bytes)
-	if err != nil {
-		return fmt.Errorf("parse netdev metric failed, error: %s", err.Error())
+	var stat parser.NetStat
+	switch *netDevSource {
+	case "procfs":
+		path := "cat"
+		args := []string{"/proc/net/dev"}
+
+		bytes, err := util.ExecCommand(context.Background(), path, args...)
+		if err != nil {
+			return fmt.Errorf("exec netdev command failed, error: %s", err.Error())
+		}
+
+		stat, err = p.ParseNetStat(bytes, filter)
+		if err != nil {
+			return fmt.Errorf("parse netdev metric failed, error: %s", err.Error())
+		}
+	case "netlink":
+		links, err := p.ParseNetLinks(filter)
+		if err != nil {
+			return fmt.Errorf("parse netlink metric failed, error: %s", err.Error())
+		}
+
+		stat = make(parser.NetStat, len(links))
+		for _, link := range links {
+			stat[link.Name] = link.Stats
+			ch <- prometheus.MustNewConstMetric(netLinkInfoDesc, prometheus.GaugeValue, 1,
+				link.Name, link.OperState, link.Kind, link.Master, link.SlaveKind)
+			ch <- prometheus.MustNewConstMetric(netLinkMTUDesc, prometheus.GaugeValue, float64(link.MTU), link.Name)
+		}
+	default:
+		return fmt.Errorf("unknown network statistics source %q", *netDevSource)
 	}
 
 	metricDescs := map[string]*prometheus.Desc{}
@@ -56,3 +85,41 @@ func (collector *NetCollector) Collect(p parser.Parser, ch chan<- prometheus.Met
 
 	return nil
 }
+
+// netDevFilter returns the device filter shared by the net, net_class and ip
+// collectors.
+func netDevFilter() (parser.NetDevFilter, error) {
+	include, exclude, err := netDevNameFilter.patterns()
+	return parser.NetDevFilter{Include: include, Exclude: exclude}, err
+}
+
+var (
+	netDevSource = flag.String(
+		"collector.net.source",
+		"procfs",
+		"Source of network device statistics for net collector, procfs (/proc/net/dev) or netlink (RTM_GETLINK).",
+	)
+
+	netLinkInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "link_info"),
+		"Link attributes from netlink, value is always 1.",
+		[]string{"device", "operstate", "kind", "master", "slave_kind"}, nil,
+	)
+	netLinkMTUDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "link_mtu_bytes"),
+		"MTU of the network device from netlink.",
+		[]string{"device"}, nil,
+	)
+
+	netDevInclude = flag.String(
+		"collector.network.device-include",
+		"",
+		"Regexp of network devices to include in net, net_class and ip collectors, all when empty.",
+	)
+	netDevExclude = flag.String(
+		"collector.network.device-exclude",
+		"",
+		"Regexp of network devices to exclude from net, net_class and ip collectors.",
+	)
+	netDevNameFilter = newNameFilter(netDevInclude, netDevExclude)
+)
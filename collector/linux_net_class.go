@@ -2,10 +2,11 @@ package collector
 
 import (
 	"exporter/parser"
+	"flag"
 	"fmt"
-	"regexp"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs/sysfs"
 )
 
 func init() {
@@ -19,131 +20,128 @@ func (collector *NetClassCollector) GetName() string {
 }
 
 func (collector *NetClassCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- netClassUpDesc
+	ch <- netClassInfoDesc
+	ch <- netClassSpeedDesc
+	for _, field := range netClassFields {
+		ch <- field.desc
+	}
 	return nil
 }
 
 func (collector *NetClassCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
-	netClass, err := p.ParseNetClass()
+	filter, err := netDevFilter()
 	if err != nil {
-		return err
+		return fmt.Errorf("network device filter: %s", err.Error())
 	}
 
-	for device, ifaceInfo := range netClass {
-		if ignoredNetClassDevicesPattern.MatchString(device) {
-			continue
-		}
+	netClass, err := p.ParseNetClass(filter)
+	if err != nil {
+		return err
+	}
 
-		upDesc := prometheus.NewDesc(
-			prometheus.BuildFQName("node", "network", "up"),
-			"Value is 1 if operstate is 'up', 0 otherwise.",
-			[]string{"device"},
-			nil,
-		)
-		upValue := 0.0
+	for _, ifaceInfo := range netClass {
+		upValue, up := 0.0, "0"
 		if ifaceInfo.OperState == "up" {
-			upValue = 1.0
+			upValue, up = 1.0, "1"
 		}
+		ch <- prometheus.MustNewConstMetric(netClassUpDesc, prometheus.GaugeValue, upValue, ifaceInfo.Name)
 
-		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, upValue, ifaceInfo.Name)
-
-		infoDesc := prometheus.NewDesc(
-			prometheus.BuildFQName("node", "network", "info"),
-			"Non-numeric data from /sys/class/net/<iface>, value is always 1.",
-			[]string{"device", "address", "broadcast", "duplex", "operstate", "ifalias"},
-			nil,
-		)
-		infoValue := 1.0
+		ch <- prometheus.MustNewConstMetric(netClassInfoDesc, prometheus.GaugeValue, 1,
+			ifaceInfo.Name, ifaceInfo.Address, ifaceInfo.Broadcast, ifaceInfo.Duplex, ifaceInfo.OperState,
+			up, ifaceInfo.IfAlias, ifaceInfo.Driver)
 
-		ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, infoValue, ifaceInfo.Name, ifaceInfo.Address, ifaceInfo.Broadcast, ifaceInfo.Duplex, ifaceInfo.OperState, ifaceInfo.IfAlias)
-
-		if ifaceInfo.AddrAssignType != nil {
-			pushMetric(ch, "address_assign_type", *ifaceInfo.AddrAssignType, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.Carrier != nil {
-			pushMetric(ch, "carrier", *ifaceInfo.Carrier, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.CarrierChanges != nil {
-			pushMetric(ch, "carrier_changes_total", *ifaceInfo.CarrierChanges, ifaceInfo.Name, prometheus.CounterValue)
-		}
-
-		if ifaceInfo.CarrierUpCount != nil {
-			pushMetric(ch, "carrier_up_changes_total", *ifaceInfo.CarrierUpCount, ifaceInfo.Name, prometheus.CounterValue)
-		}
-
-		if ifaceInfo.CarrierDownCount != nil {
-			pushMetric(ch, "carrier_down_changes_total", *ifaceInfo.CarrierDownCount, ifaceInfo.Name, prometheus.CounterValue)
-		}
-
-		if ifaceInfo.DevID != nil {
-			pushMetric(ch, "device_id", *ifaceInfo.DevID, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.Dormant != nil {
-			pushMetric(ch, "dormant", *ifaceInfo.Dormant, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.Flags != nil {
-			pushMetric(ch, "flags", *ifaceInfo.Flags, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.IfIndex != nil {
-			pushMetric(ch, "iface_id", *ifaceInfo.IfIndex, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.IfLink != nil {
-			pushMetric(ch, "iface_link", *ifaceInfo.IfLink, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.LinkMode != nil {
-			pushMetric(ch, "iface_link_mode", *ifaceInfo.LinkMode, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.MTU != nil {
-			pushMetric(ch, "mtu_bytes", *ifaceInfo.MTU, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.NameAssignType != nil {
-			pushMetric(ch, "name_assign_type", *ifaceInfo.NameAssignType, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.NetDevGroup != nil {
-			pushMetric(ch, "net_dev_group", *ifaceInfo.NetDevGroup, ifaceInfo.Name, prometheus.GaugeValue)
+		for _, field := range netClassFields {
+			if value := field.value(ifaceInfo.NetClassIface); value != nil {
+				ch <- prometheus.MustNewConstMetric(field.desc, field.valueType, float64(*value), ifaceInfo.Name)
+			}
 		}
 
 		if ifaceInfo.Speed != nil {
 			// Some devices return -1 if the speed is unknown.
-			if *ifaceInfo.Speed >= 0 || !netclassInvalidSpeed {
-				speedBytes := int64(*ifaceInfo.Speed * 1000 * 1000 / 8)
-				pushMetric(ch, "speed_bytes", speedBytes, ifaceInfo.Name, prometheus.GaugeValue)
+			if *ifaceInfo.Speed >= 0 || *netClassInvalidSpeed {
+				speedBytes := float64(*ifaceInfo.Speed * 1000 * 1000 / 8)
+				ch <- prometheus.MustNewConstMetric(netClassSpeedDesc, prometheus.GaugeValue, speedBytes, ifaceInfo.Name)
 			}
 		}
-
-		if ifaceInfo.TxQueueLen != nil {
-			pushMetric(ch, "transmit_queue_length", *ifaceInfo.TxQueueLen, ifaceInfo.Name, prometheus.GaugeValue)
-		}
-
-		if ifaceInfo.Type != nil {
-			pushMetric(ch, "protocol_type", *ifaceInfo.Type, ifaceInfo.Name, prometheus.GaugeValue)
-		}
 	}
 	return nil
 }
 
-func pushMetric(ch chan<- prometheus.Metric, name string, value int64, ifaceName string, valueType prometheus.ValueType) {
-	fieldDesc := prometheus.NewDesc(
-		prometheus.BuildFQName("node", "network", name),
-		fmt.Sprintf("%s value of /sys/class/net/<iface>.", name),
-		[]string{"device"},
-		nil,
-	)
+// netClassField is a numeric attribute of /sys/class/net/<iface>.
+type netClassField struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     func(sysfs.NetClassIface) *int64
+}
 
-	ch <- prometheus.MustNewConstMetric(fieldDesc, valueType, float64(value), ifaceName)
+func newNetClassField(name, help string, valueType prometheus.ValueType, value func(sysfs.NetClassIface) *int64) netClassField {
+	return netClassField{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName("node", "network", name),
+			help,
+			[]string{"device"}, nil,
+		),
+		valueType: valueType,
+		value:     value,
+	}
 }
 
 var (
-	netclassIgnoredDevices        = "^$"
-	netclassInvalidSpeed          = false
-	ignoredNetClassDevicesPattern = regexp.MustCompile(netclassIgnoredDevices)
+	netClassInvalidSpeed = flag.Bool(
+		"collector.netclass.invalid-speed",
+		false,
+		"Report node_network_speed_bytes for devices with an unknown speed (-1).",
+	)
+
+	netClassUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "up"),
+		"Value is 1 if operstate is 'up', 0 otherwise.",
+		[]string{"device"}, nil,
+	)
+	netClassInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "info"),
+		"Non-numeric data from /sys/class/net/<iface>, value is always 1.",
+		[]string{"device", "address", "broadcast", "duplex", "operstate", "up", "ifalias", "driver"}, nil,
+	)
+	netClassSpeedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "speed_bytes"),
+		"Link speed of the network device in bytes per second.",
+		[]string{"device"}, nil,
+	)
+
+	netClassFields = []netClassField{
+		newNetClassField("address_assign_type", "How the device address was assigned, from addr_assign_type.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.AddrAssignType }),
+		newNetClassField("carrier", "Value is 1 if the physical link is up, 0 otherwise.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.Carrier }),
+		newNetClassField("carrier_changes_total", "Number of times the physical link changed state.", prometheus.CounterValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.CarrierChanges }),
+		newNetClassField("carrier_up_changes_total", "Number of times the physical link went up.", prometheus.CounterValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.CarrierUpCount }),
+		newNetClassField("carrier_down_changes_total", "Number of times the physical link went down.", prometheus.CounterValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.CarrierDownCount }),
+		newNetClassField("device_id", "Port of a device sharing its address with other devices, from dev_id.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.DevID }),
+		newNetClassField("dormant", "Value is 1 if the device is dormant, waiting for an external event.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.Dormant }),
+		newNetClassField("flags", "Device flags (IFF_*) as a bitmask.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.Flags }),
+		newNetClassField("iface_id", "Interface index of the device.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.IfIndex }),
+		newNetClassField("iface_link", "Interface index of the device this one is stacked on, its own index otherwise.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.IfLink }),
+		newNetClassField("iface_link_mode", "Link mode of the device, 1 if it waits for a supplicant before going up.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.LinkMode }),
+		newNetClassField("mtu_bytes", "MTU of the network device.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.MTU }),
+		newNetClassField("name_assign_type", "How the device name was assigned, from name_assign_type.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.NameAssignType }),
+		newNetClassField("net_dev_group", "Network device group of the device.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.NetDevGroup }),
+		newNetClassField("transmit_queue_length", "Transmit queue length of the device.", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.TxQueueLen }),
+		newNetClassField("protocol_type", "Hardware type of the device (ARPHRD_*).", prometheus.GaugeValue,
+			func(iface sysfs.NetClassIface) *int64 { return iface.Type }),
+	}
 )
@@ -2,18 +2,28 @@ package collector
 
 import (
 	"exporter/parser"
+	"flag"
 	"fmt"
-	"regexp"
+	"log"
 	"strconv"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 func init() {
-	registerCollector(new(NetStatCollector))
+	registerCollector(&NetStatCollector{
+		descs:        map[string]*prometheus.Desc{},
+		invalidField: map[string]bool{},
+	})
 }
 
-type NetStatCollector struct{}
+type NetStatCollector struct {
+	mu    sync.Mutex
+	descs map[string]*prometheus.Desc
+	// Fields whose unparsable value was already logged.
+	invalidField map[string]bool
+}
 
 func (collector *NetStatCollector) GetName() string {
 	return "net_stat"
@@ -24,35 +34,72 @@ func (collector *NetStatCollector) Describe(ch chan<- *prometheus.Desc) error {
 }
 
 func (collector *NetStatCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	pattern, err := netStatFieldsPattern.regexp()
+	if err != nil {
+		return fmt.Errorf("netstat fields: %s", err.Error())
+	}
+
 	netStats, err := p.ParseNetStatInfo()
 	if err != nil {
-		return err
+		return fmt.Errorf("parse netstat metric failed, error: %s", err.Error())
 	}
 
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
 	for protocol, protocolStats := range netStats {
 		for name, value := range protocolStats {
 			key := protocol + "_" + name
+			if !pattern.MatchString(key) {
+				continue
+			}
+
 			v, err := strconv.ParseFloat(value, 64)
 			if err != nil {
-				return fmt.Errorf("invalid value %s in netstats: %w", value, err)
-			}
-			if !netStatFieldsPattern.MatchString(key) {
+				if !collector.invalidField[key] {
+					collector.invalidField[key] = true
+					log.Println(fmt.Sprintf("[WARN] invalid value %s for netstat field %s, skipping it", value, key))
+				}
 				continue
 			}
-			ch <- prometheus.MustNewConstMetric(
-				prometheus.NewDesc(
+
+			desc, ok := collector.descs[key]
+			if !ok {
+				desc = prometheus.NewDesc(
 					prometheus.BuildFQName("node", "netstat", key),
 					fmt.Sprintf("Statistic %s.", protocol+name),
 					nil, nil,
-				),
-				prometheus.UntypedValue, v,
-			)
+				)
+				collector.descs[key] = desc
+			}
+
+			valueType := prometheus.CounterValue
+			if netStatGauges[key] {
+				valueType = prometheus.GaugeValue
+			}
+			ch <- prometheus.MustNewConstMetric(desc, valueType, v)
 		}
 	}
 	return nil
 }
 
 var (
-	netStatFields        = "^(.*_(InErrors|InErrs)|Ip_Forwarding|Ip(6|Ext)_(InOctets|OutOctets)|Icmp6?_(InMsgs|OutMsgs)|TcpExt_(Listen.*|Syncookies.*|TCPSynRetrans)|Tcp_(ActiveOpens|InSegs|OutSegs|OutRsts|PassiveOpens|RetransSegs|CurrEstab)|Udp6?_(InDatagrams|OutDatagrams|NoPorts|RcvbufErrors|SndbufErrors))$"
-	netStatFieldsPattern = regexp.MustCompile(netStatFields)
+	netStatFields = flag.String(
+		"collector.netstat.fields",
+		"^(.*_(InErrors|InErrs)|Ip_Forwarding|Ip(6|Ext)_(InOctets|OutOctets)|Icmp6?_(InMsgs|OutMsgs)|TcpExt_(Listen.*|Syncookies.*|TCPSynRetrans)|Tcp_(ActiveOpens|InSegs|OutSegs|OutRsts|PassiveOpens|RetransSegs|CurrEstab)|Udp6?_(InDatagrams|OutDatagrams|NoPorts|RcvbufErrors|SndbufErrors))$",
+		"Regexp of fields to return for netstat collector.",
+	)
+	netStatFieldsPattern = newFlagRegexp(netStatFields)
+
+	// netStatGauges are the fields that hold a current value or a setting,
+	// all other fields count events since boot.
+	netStatGauges = map[string]bool{
+		"Ip_Forwarding":    true,
+		"Ip_DefaultTTL":    true,
+		"Tcp_RtoAlgorithm": true,
+		"Tcp_RtoMin":       true,
+		"Tcp_RtoMax":       true,
+		"Tcp_MaxConn":      true,
+		"Tcp_CurrEstab":    true,
+	}
 )
@@ -0,0 +1,264 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+func init() {
+	registerCollector(new(NFSCollector))
+}
+
+type NFSCollector struct{}
+
+func (collector *NFSCollector) GetName() string {
+	return "nfs"
+}
+
+func (collector *NFSCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- nfsConnectionsDesc
+	ch <- nfsPacketsDesc
+	ch <- nfsRPCsDesc
+	ch <- nfsRPCRetransmissionsDesc
+	ch <- nfsRPCAuthRefreshesDesc
+	ch <- nfsRequestsDesc
+	ch <- nfsMountInfoDesc
+	ch <- nfsMountAgeDesc
+	ch <- nfsMountReadBytesDesc
+	ch <- nfsMountWriteBytesDesc
+	for _, field := range nfsOperationFields {
+		ch <- field.desc
+	}
+	return nil
+}
+
+func (collector *NFSCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// Without the nfs client module there are no statistics and no nfs mounts.
+	if _, err := os.Stat("/proc/net/rpc/nfs"); os.IsNotExist(err) {
+		return nil
+	}
+
+	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/rpc/nfs")
+	if err != nil {
+		return fmt.Errorf("get nfs metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseNFSClientStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse nfs metric failed, error: %s", err.Error())
+	}
+
+	ch <- prometheus.MustNewConstMetric(nfsConnectionsDesc, prometheus.CounterValue, float64(stats.Network.TCPConnect))
+	ch <- prometheus.MustNewConstMetric(nfsPacketsDesc, prometheus.CounterValue, float64(stats.Network.UDPCount), "udp")
+	ch <- prometheus.MustNewConstMetric(nfsPacketsDesc, prometheus.CounterValue, float64(stats.Network.TCPCount), "tcp")
+	ch <- prometheus.MustNewConstMetric(nfsRPCsDesc, prometheus.CounterValue, float64(stats.ClientRPC.RPCCount))
+	ch <- prometheus.MustNewConstMetric(nfsRPCRetransmissionsDesc, prometheus.CounterValue, float64(stats.ClientRPC.Retransmissions))
+	ch <- prometheus.MustNewConstMetric(nfsRPCAuthRefreshesDesc, prometheus.CounterValue, float64(stats.ClientRPC.AuthRefreshes))
+
+	for version, procedures := range map[string]interface{}{
+		"2": stats.V2Stats,
+		"3": stats.V3Stats,
+		"4": stats.ClientV4Stats,
+	} {
+		for method, value := range nfsProcedures(procedures) {
+			ch <- prometheus.MustNewConstMetric(nfsRequestsDesc, prometheus.CounterValue, value, version, method)
+		}
+	}
+
+	mounts, err := p.ParseNFSMountStats()
+	if err != nil {
+		return fmt.Errorf("parse nfs mountstats failed, error: %s", err.Error())
+	}
+
+	// A mount point that was mounted over shows up more than once, only the
+	// first entry is reported.
+	seen := make(map[string]bool, len(mounts))
+	for _, mount := range mounts {
+		key := mount.Export + " " + mount.MountPoint
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		ch <- prometheus.MustNewConstMetric(nfsMountInfoDesc, prometheus.GaugeValue, 1,
+			mount.Export, mount.MountPoint, mount.Opts["vers"], mount.Transport.Protocol)
+		ch <- prometheus.MustNewConstMetric(nfsMountAgeDesc, prometheus.GaugeValue, mount.Age.Seconds(),
+			mount.Export, mount.MountPoint)
+		ch <- prometheus.MustNewConstMetric(nfsMountReadBytesDesc, prometheus.CounterValue, float64(mount.Bytes.ReadTotal),
+			mount.Export, mount.MountPoint)
+		ch <- prometheus.MustNewConstMetric(nfsMountWriteBytesDesc, prometheus.CounterValue, float64(mount.Bytes.WriteTotal),
+			mount.Export, mount.MountPoint)
+
+		for _, operation := range mount.Operations {
+			for _, field := range nfsOperationFields {
+				ch <- prometheus.MustNewConstMetric(field.desc, prometheus.CounterValue, field.value(operation),
+					mount.Export, mount.MountPoint, operation.Operation)
+			}
+		}
+	}
+
+	return nil
+}
+
+// nfsProcedures returns the per-procedure counters of a procfs nfs stats
+// struct, keyed by the lower-cased field name.
+func nfsProcedures(stats interface{}) map[string]float64 {
+	var (
+		value      = reflect.ValueOf(stats)
+		procedures = make(map[string]float64, value.NumField())
+	)
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Uint64 {
+			continue
+		}
+		procedures[strings.ToLower(value.Type().Field(i).Name)] = float64(field.Uint())
+	}
+
+	return procedures
+}
+
+type nfsOperationField struct {
+	desc  *prometheus.Desc
+	value func(procfs.NFSOperationStats) float64
+}
+
+var (
+	nfsConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "connections_total"),
+		"Number of TCP connections made by the NFS client.",
+		nil, nil,
+	)
+	nfsPacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "packets_total"),
+		"Number of packets sent by the NFS client.",
+		[]string{"protocol"}, nil,
+	)
+	nfsRPCsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "rpcs_total"),
+		"Number of RPCs made by the NFS client.",
+		nil, nil,
+	)
+	nfsRPCRetransmissionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "rpc_retransmissions_total"),
+		"Number of RPCs retransmitted by the NFS client.",
+		nil, nil,
+	)
+	nfsRPCAuthRefreshesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "rpc_authentication_refreshes_total"),
+		"Number of RPC authentication refreshes by the NFS client.",
+		nil, nil,
+	)
+	nfsRequestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "requests_total"),
+		"Number of NFS procedures invoked by the client.",
+		[]string{"version", "method"}, nil,
+	)
+
+	nfsMountLabelNames = []string{"export", "mountpoint"}
+
+	nfsMountInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "mount_info"),
+		"NFS mount information, value is always 1.",
+		[]string{"export", "mountpoint", "version", "protocol"}, nil,
+	)
+	nfsMountAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "mount_age_seconds"),
+		"Time since the NFS export was mounted.",
+		nfsMountLabelNames, nil,
+	)
+	nfsMountReadBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "mount_read_bytes_total"),
+		"Number of bytes read from the NFS server.",
+		nfsMountLabelNames, nil,
+	)
+	nfsMountWriteBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfs", "mount_write_bytes_total"),
+		"Number of bytes written to the NFS server.",
+		nfsMountLabelNames, nil,
+	)
+
+	nfsOperationLabelNames = []string{"export", "mountpoint", "operation"}
+
+	nfsOperationFields = []nfsOperationField{
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_requests_total"),
+				"Number of requests performed for an NFS operation.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 { return float64(stats.Requests) },
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_transmissions_total"),
+				"Number of RPCs transmitted for an NFS operation, including retransmissions.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 { return float64(stats.Transmissions) },
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_major_timeouts_total"),
+				"Number of major timeouts of an NFS operation.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 { return float64(stats.MajorTimeouts) },
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_sent_bytes_total"),
+				"Number of bytes sent for an NFS operation, including RPC headers.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 { return float64(stats.BytesSent) },
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_received_bytes_total"),
+				"Number of bytes received for an NFS operation, including RPC headers.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 { return float64(stats.BytesReceived) },
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_queue_time_seconds_total"),
+				"Time requests of an NFS operation spent queued before being transmitted.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 {
+				return float64(stats.CumulativeQueueMilliseconds) / 1000
+			},
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_rtt_seconds_total"),
+				"Time between transmitting requests of an NFS operation and receiving the reply.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 {
+				return float64(stats.CumulativeTotalResponseMilliseconds) / 1000
+			},
+		},
+		{
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "nfs", "mount_operation_execute_time_seconds_total"),
+				"Time between queueing requests of an NFS operation and completely handling them.",
+				nfsOperationLabelNames, nil,
+			),
+			func(stats procfs.NFSOperationStats) float64 {
+				return float64(stats.CumulativeTotalRequestMilliseconds) / 1000
+			},
+		},
+	}
+)
@@ -0,0 +1,203 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(NFSDCollector))
+}
+
+type NFSDCollector struct{}
+
+func (collector *NFSDCollector) GetName() string {
+	return "nfsd"
+}
+
+func (collector *NFSDCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- nfsdReplyCacheDesc
+	ch <- nfsdFileHandlesStaleDesc
+	ch <- nfsdDiskBytesReadDesc
+	ch <- nfsdDiskBytesWrittenDesc
+	ch <- nfsdThreadsDesc
+	ch <- nfsdThreadsFullDesc
+	ch <- nfsdConnectionsDesc
+	ch <- nfsdPacketsDesc
+	ch <- nfsdRPCsDesc
+	ch <- nfsdRPCErrorsDesc
+	ch <- nfsdRequestsDesc
+	ch <- nfsdV4OperationsDesc
+	for _, field := range nfsdPoolFields {
+		ch <- field.desc
+	}
+	return nil
+}
+
+func (collector *NFSDCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// Without the nfsd module the host does not serve any exports.
+	if _, err := os.Stat("/proc/net/rpc/nfsd"); os.IsNotExist(err) {
+		return nil
+	}
+
+	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/rpc/nfsd")
+	if err != nil {
+		return fmt.Errorf("get nfsd metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseNFSServerStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse nfsd metric failed, error: %s", err.Error())
+	}
+
+	ch <- prometheus.MustNewConstMetric(nfsdReplyCacheDesc, prometheus.CounterValue, float64(stats.ReplyCache.Hits), "hit")
+	ch <- prometheus.MustNewConstMetric(nfsdReplyCacheDesc, prometheus.CounterValue, float64(stats.ReplyCache.Misses), "miss")
+	ch <- prometheus.MustNewConstMetric(nfsdReplyCacheDesc, prometheus.CounterValue, float64(stats.ReplyCache.NoCache), "nocache")
+	ch <- prometheus.MustNewConstMetric(nfsdFileHandlesStaleDesc, prometheus.CounterValue, float64(stats.FileHandles.Stale))
+	ch <- prometheus.MustNewConstMetric(nfsdDiskBytesReadDesc, prometheus.CounterValue, float64(stats.InputOutput.Read))
+	ch <- prometheus.MustNewConstMetric(nfsdDiskBytesWrittenDesc, prometheus.CounterValue, float64(stats.InputOutput.Write))
+	ch <- prometheus.MustNewConstMetric(nfsdThreadsDesc, prometheus.GaugeValue, float64(stats.Threads.Threads))
+	ch <- prometheus.MustNewConstMetric(nfsdThreadsFullDesc, prometheus.CounterValue, float64(stats.Threads.FullCnt))
+	ch <- prometheus.MustNewConstMetric(nfsdConnectionsDesc, prometheus.CounterValue, float64(stats.Network.TCPConnect))
+	ch <- prometheus.MustNewConstMetric(nfsdPacketsDesc, prometheus.CounterValue, float64(stats.Network.UDPCount), "udp")
+	ch <- prometheus.MustNewConstMetric(nfsdPacketsDesc, prometheus.CounterValue, float64(stats.Network.TCPCount), "tcp")
+	ch <- prometheus.MustNewConstMetric(nfsdRPCsDesc, prometheus.CounterValue, float64(stats.ServerRPC.RPCCount))
+	ch <- prometheus.MustNewConstMetric(nfsdRPCErrorsDesc, prometheus.CounterValue, float64(stats.ServerRPC.BadFmt), "fmt")
+	ch <- prometheus.MustNewConstMetric(nfsdRPCErrorsDesc, prometheus.CounterValue, float64(stats.ServerRPC.BadAuth), "auth")
+	ch <- prometheus.MustNewConstMetric(nfsdRPCErrorsDesc, prometheus.CounterValue, float64(stats.ServerRPC.BadcInt), "client")
+
+	for version, procedures := range map[string]interface{}{
+		"2": stats.V2Stats,
+		"3": stats.V3Stats,
+		"4": stats.ServerV4Stats,
+	} {
+		for method, value := range nfsProcedures(procedures) {
+			ch <- prometheus.MustNewConstMetric(nfsdRequestsDesc, prometheus.CounterValue, value, version, method)
+		}
+	}
+	for operation, value := range nfsProcedures(stats.V4Ops) {
+		ch <- prometheus.MustNewConstMetric(nfsdV4OperationsDesc, prometheus.CounterValue, value, operation)
+	}
+
+	// pool_stats is only available while the nfsd filesystem is mounted.
+	if _, err := os.Stat("/proc/fs/nfsd/pool_stats"); os.IsNotExist(err) {
+		return nil
+	}
+
+	bytes, err = util.ExecCommand(context.Background(), "cat", "/proc/fs/nfsd/pool_stats")
+	if err != nil {
+		return fmt.Errorf("get nfsd pool metric failed, error: %s", err.Error())
+	}
+
+	pools, err := p.ParseNFSDPoolStats(bytes)
+	if err != nil {
+		return fmt.Errorf("parse nfsd pool metric failed, error: %s", err.Error())
+	}
+
+	for _, pool := range pools {
+		for key, field := range nfsdPoolFields {
+			value, ok := pool.Values[key]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(field.desc, prometheus.CounterValue, value, pool.Pool)
+		}
+	}
+
+	return nil
+}
+
+var (
+	nfsdReplyCacheDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "reply_cache_requests_total"),
+		"Number of NFS requests looked up in the reply cache, by result.",
+		[]string{"result"}, nil,
+	)
+	nfsdFileHandlesStaleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "file_handles_stale_total"),
+		"Number of stale file handles returned by the NFS server.",
+		nil, nil,
+	)
+	nfsdDiskBytesReadDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "disk_bytes_read_total"),
+		"Number of bytes read from disk by the NFS server.",
+		nil, nil,
+	)
+	nfsdDiskBytesWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "disk_bytes_written_total"),
+		"Number of bytes written to disk by the NFS server.",
+		nil, nil,
+	)
+	nfsdThreadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "server_threads"),
+		"Number of NFS server threads.",
+		nil, nil,
+	)
+	nfsdThreadsFullDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "server_threads_full_total"),
+		"Number of times all NFS server threads were busy, always 0 on recent kernels.",
+		nil, nil,
+	)
+	nfsdConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "connections_total"),
+		"Number of TCP connections accepted by the NFS server.",
+		nil, nil,
+	)
+	nfsdPacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "packets_total"),
+		"Number of packets received by the NFS server.",
+		[]string{"protocol"}, nil,
+	)
+	nfsdRPCsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "rpcs_total"),
+		"Number of RPCs received by the NFS server.",
+		nil, nil,
+	)
+	nfsdRPCErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "rpc_errors_total"),
+		"Number of RPCs rejected by the NFS server, by reason.",
+		[]string{"error"}, nil,
+	)
+	nfsdRequestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "requests_total"),
+		"Number of NFS procedures handled by the server.",
+		[]string{"version", "method"}, nil,
+	)
+	nfsdV4OperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "nfsd", "v4_operations_total"),
+		"Number of NFSv4 compound operations handled by the server.",
+		[]string{"operation"}, nil,
+	)
+
+	// Columns of /proc/fs/nfsd/pool_stats. A growing sockets-enqueued count
+	// means requests arrived while no thread was idle.
+	nfsdPoolFields = map[string]struct {
+		desc *prometheus.Desc
+	}{
+		"packets-arrived": {prometheus.NewDesc(
+			prometheus.BuildFQName("node", "nfsd", "pool_packets_arrived_total"),
+			"Number of packets that arrived on the sockets of an nfsd thread pool.",
+			[]string{"pool"}, nil,
+		)},
+		"sockets-enqueued": {prometheus.NewDesc(
+			prometheus.BuildFQName("node", "nfsd", "pool_sockets_enqueued_total"),
+			"Number of times a socket was queued because no nfsd thread was idle.",
+			[]string{"pool"}, nil,
+		)},
+		"threads-woken": {prometheus.NewDesc(
+			prometheus.BuildFQName("node", "nfsd", "pool_threads_woken_total"),
+			"Number of times an idle nfsd thread was woken to handle a socket.",
+			[]string{"pool"}, nil,
+		)},
+		"threads-timedout": {prometheus.NewDesc(
+			prometheus.BuildFQName("node", "nfsd", "pool_threads_timedout_total"),
+			"Number of times an nfsd thread timed out waiting for work.",
+			[]string{"pool"}, nil,
+		)},
+	}
+)
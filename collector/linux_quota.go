@@ -0,0 +1,146 @@
+package collector
+
+import (
+	"errors"
+	"exporter/parser"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(QuotaCollector))
+}
+
+type QuotaCollector struct {
+	permissionOnce sync.Once
+}
+
+func (collector *QuotaCollector) GetName() string {
+	return "quota"
+}
+
+func (collector *QuotaCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- quotaBlocksUsedDesc
+	ch <- quotaBlocksSoftLimitDesc
+	ch <- quotaBlocksHardLimitDesc
+	ch <- quotaInodesUsedDesc
+	ch <- quotaInodesSoftLimitDesc
+	ch <- quotaInodesHardLimitDesc
+	return nil
+}
+
+func (collector *QuotaCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// The collector is opt-in, nothing is queried until mount points are configured.
+	if *quotaMountPoints == "" {
+		return nil
+	}
+
+	mountPointsRe, err := quotaMountPointsRegexp.regexp()
+	if err != nil {
+		return fmt.Errorf("quota mount points: %s", err.Error())
+	}
+	idsRe, err := quotaIDsRegexp.regexp()
+	if err != nil {
+		return fmt.Errorf("quota ids: %s", err.Error())
+	}
+
+	mounts, err := fileSystemMounts(p)
+	if err != nil {
+		return fmt.Errorf("get filesystem mounts failed, error: %s", err.Error())
+	}
+
+	// Bind mounts share the quotas of their device, query each device once.
+	seen := map[string]bool{}
+	for _, mount := range mounts {
+		mountPoint := mount.MountPoint
+		if !mountPointsRe.MatchString(mountPoint) || !strings.HasPrefix(mount.Device, "/dev/") || seen[mount.Device] {
+			continue
+		}
+		seen[mount.Device] = true
+
+		quotas, err := p.ParseQuotas(mount.Device)
+		if errors.Is(err, os.ErrPermission) {
+			collector.permissionOnce.Do(func() {
+				log.Println("[WARN] quotactl requires root, quota metrics are not exported")
+			})
+			return nil
+		}
+		if err != nil {
+			log.Println(fmt.Sprintf("[ERROR] get quota metric of %s failed, error: %s", mountPoint, err.Error()))
+			continue
+		}
+
+		for _, quota := range quotas {
+			id := strconv.FormatUint(uint64(quota.ID), 10)
+			if *quotaIDs != "" && !idsRe.MatchString(quota.Type+":"+id) {
+				continue
+			}
+
+			labels := []string{mountPoint, quota.Type, id}
+			ch <- prometheus.MustNewConstMetric(quotaBlocksUsedDesc, prometheus.GaugeValue, quota.BlocksUsed, labels...)
+			ch <- prometheus.MustNewConstMetric(quotaBlocksSoftLimitDesc, prometheus.GaugeValue, quota.BlocksSoftLimit, labels...)
+			ch <- prometheus.MustNewConstMetric(quotaBlocksHardLimitDesc, prometheus.GaugeValue, quota.BlocksHardLimit, labels...)
+			ch <- prometheus.MustNewConstMetric(quotaInodesUsedDesc, prometheus.GaugeValue, quota.InodesUsed, labels...)
+			ch <- prometheus.MustNewConstMetric(quotaInodesSoftLimitDesc, prometheus.GaugeValue, quota.InodesSoftLimit, labels...)
+			ch <- prometheus.MustNewConstMetric(quotaInodesHardLimitDesc, prometheus.GaugeValue, quota.InodesHardLimit, labels...)
+		}
+	}
+
+	return nil
+}
+
+var (
+	quotaMountPoints = flag.String(
+		"collector.quota.mount-points",
+		"",
+		"Regexp of mount points to report quotas for, the quota collector is disabled when empty.",
+	)
+	quotaMountPointsRegexp = newFlagRegexp(quotaMountPoints)
+
+	quotaIDs = flag.String(
+		"collector.quota.ids",
+		"",
+		"Regexp of quotas to report, matched against <type>:<id> such as user:1000 or project:42, all when empty.",
+	)
+	quotaIDsRegexp = newFlagRegexp(quotaIDs)
+
+	quotaLabelNames = []string{"mountpoint", "type", "id"}
+
+	quotaBlocksUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "blocks_used_bytes"),
+		"Disk space used by a user, group or project.",
+		quotaLabelNames, nil,
+	)
+	quotaBlocksSoftLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "blocks_soft_limit_bytes"),
+		"Soft limit on the disk space of a user, group or project, 0 if unlimited.",
+		quotaLabelNames, nil,
+	)
+	quotaBlocksHardLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "blocks_hard_limit_bytes"),
+		"Hard limit on the disk space of a user, group or project, 0 if unlimited.",
+		quotaLabelNames, nil,
+	)
+	quotaInodesUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "inodes_used"),
+		"Number of inodes used by a user, group or project.",
+		quotaLabelNames, nil,
+	)
+	quotaInodesSoftLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "inodes_soft_limit"),
+		"Soft limit on the inodes of a user, group or project, 0 if unlimited.",
+		quotaLabelNames, nil,
+	)
+	quotaInodesHardLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "quota", "inodes_hard_limit"),
+		"Hard limit on the inodes of a user, group or project, 0 if unlimited.",
+		quotaLabelNames, nil,
+	)
+)
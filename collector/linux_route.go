@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"exporter/parser"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(RouteCollector))
+}
+
+type RouteCollector struct{}
+
+func (collector *RouteCollector) GetName() string {
+	return "route"
+}
+
+func (collector *RouteCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- routesDesc
+	ch <- routeInfoDesc
+	return nil
+}
+
+func (collector *RouteCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	routes, err := p.ParseRoutes()
+	if err != nil {
+		return fmt.Errorf("parse route metric failed, error: %s", err.Error())
+	}
+
+	type tableKey struct{ family, table string }
+	var (
+		counts = map[tableKey]float64{}
+		// Multipath routes may list the same path more than once.
+		seen = map[[5]string]bool{}
+	)
+	for _, route := range routes {
+		counts[tableKey{route.Family, route.Table}]++
+		if !route.Default {
+			continue
+		}
+
+		metric := strconv.FormatUint(uint64(route.Metric), 10)
+		for _, nextHop := range route.NextHops {
+			labels := [5]string{route.Family, route.Table, nextHop.Gateway, nextHop.Device, metric}
+			if seen[labels] {
+				continue
+			}
+			seen[labels] = true
+			ch <- prometheus.MustNewConstMetric(routeInfoDesc, prometheus.GaugeValue, 1, labels[:]...)
+		}
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(routesDesc, prometheus.GaugeValue, count, k.family, k.table)
+	}
+
+	return nil
+}
+
+var (
+	routesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "routes"),
+		"Number of routes by address family and routing table.",
+		[]string{"family", "table"}, nil,
+	)
+	routeInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "network", "route_info"),
+		"Non-numeric data about default routes, value is always 1. Multipath routes report each path.",
+		[]string{"family", "table", "gateway", "device", "metric"}, nil,
+	)
+)
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"errors"
+	"exporter/parser"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(SlabInfoCollector))
+}
+
+type SlabInfoCollector struct {
+	permissionOnce sync.Once
+}
+
+func (collector *SlabInfoCollector) GetName() string {
+	return "slabinfo"
+}
+
+func (collector *SlabInfoCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- slabActiveObjectsDesc
+	ch <- slabObjectsDesc
+	ch <- slabObjectSizeDesc
+	ch <- slabSizeDesc
+	return nil
+}
+
+func (collector *SlabInfoCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	slabs, err := p.ParseSlabInfo()
+	if errors.Is(err, os.ErrPermission) {
+		// Not running as root is a deployment choice, not a scrape failure.
+		collector.permissionOnce.Do(func() {
+			log.Println("[WARN] slabinfo is only readable by root, slab metrics are not exported")
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("parse slabinfo metric failed, error: %s", err.Error())
+	}
+
+	pageSize := float64(os.Getpagesize())
+	for _, slab := range slabs {
+		ignored, err := slabFilter.ignored(slab.Name)
+		if err != nil {
+			return fmt.Errorf("slabinfo filter: %s", err.Error())
+		}
+		if ignored {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(slabActiveObjectsDesc, prometheus.GaugeValue, slab.ActiveObjects, slab.Name)
+		ch <- prometheus.MustNewConstMetric(slabObjectsDesc, prometheus.GaugeValue, slab.Objects, slab.Name)
+		ch <- prometheus.MustNewConstMetric(slabObjectSizeDesc, prometheus.GaugeValue, slab.ObjectSize, slab.Name)
+		ch <- prometheus.MustNewConstMetric(slabSizeDesc, prometheus.GaugeValue, slab.Slabs*slab.PagesPerSlab*pageSize, slab.Name)
+	}
+
+	return nil
+}
+
+var (
+	slabInclude = flag.String(
+		"collector.slabinfo.slabs-include",
+		"",
+		"Regexp of slab caches to include in slabinfo collector.",
+	)
+	slabExclude = flag.String(
+		"collector.slabinfo.slabs-exclude",
+		"",
+		"Regexp of slab caches to exclude from slabinfo collector.",
+	)
+	slabFilter = newNameFilter(slabInclude, slabExclude)
+
+	slabLabelNames = []string{"slab"}
+
+	slabActiveObjectsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "slabinfo", "active_objects"),
+		"The number of objects that are currently active (i.e., in use).",
+		slabLabelNames, nil,
+	)
+
+	slabObjectsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "slabinfo", "objects"),
+		"The total number of allocated objects.",
+		slabLabelNames, nil,
+	)
+
+	slabObjectSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "slabinfo", "object_size_bytes"),
+		"The size of objects in this slab, in bytes.",
+		slabLabelNames, nil,
+	)
+
+	slabSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "slabinfo", "size_bytes"),
+		"The memory held by all slabs of this cache, in bytes.",
+		slabLabelNames, nil,
+	)
+)
@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(SockStatCollector))
+}
+
+type SockStatCollector struct{}
+
+func (collector *SockStatCollector) GetName() string {
+	return "sockstat"
+}
+
+func (collector *SockStatCollector) Describe(ch chan<- *prometheus.Desc) error {
+	for _, desc := range sockStatDescs {
+		ch <- desc
+	}
+	return nil
+}
+
+func (collector *SockStatCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/sockstat")
+	if err != nil {
+		return fmt.Errorf("get sockstat metric failed, error: %s", err.Error())
+	}
+
+	stat, err := p.ParseSockStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse sockstat metric failed, error: %s", err.Error())
+	}
+
+	// sockstat6 is missing when IPv6 is disabled.
+	if _, err := os.Stat("/proc/net/sockstat6"); err == nil {
+		bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/sockstat6")
+		if err != nil {
+			return fmt.Errorf("get sockstat6 metric failed, error: %s", err.Error())
+		}
+
+		stat6, err := p.ParseSockStat(bytes)
+		if err != nil {
+			return fmt.Errorf("parse sockstat6 metric failed, error: %s", err.Error())
+		}
+		for protocol, fields := range stat6 {
+			stat[protocol] = fields
+		}
+	}
+
+	pageSize := float64(os.Getpagesize())
+	for protocol, fields := range stat {
+		for field, value := range fields {
+			emitSockStat(ch, protocol, field, value)
+			// TCP and UDP account their memory in pages.
+			if field == "mem" {
+				emitSockStat(ch, protocol, "mem_bytes", value*pageSize)
+			}
+		}
+	}
+
+	return nil
+}
+
+// emitSockStat reports a field, fields missing from sockStatDescs are skipped.
+func emitSockStat(ch chan<- prometheus.Metric, protocol, field string, value float64) {
+	if desc, ok := sockStatDescs[protocol+"_"+field]; ok {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+}
+
+func newSockStatDesc(protocol, field, help string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName("node", "sockstat", protocol+"_"+field),
+		help,
+		nil, nil,
+	)
+}
+
+// sockStatDescs are the fields of /proc/net/sockstat and sockstat6, keyed by
+// <protocol>_<field>.
+var sockStatDescs = map[string]*prometheus.Desc{
+	"sockets_used":   newSockStatDesc("sockets", "used", "Number of IPv4 sockets in use."),
+	"TCP_inuse":      newSockStatDesc("TCP", "inuse", "Number of TCP sockets in use."),
+	"TCP_orphan":     newSockStatDesc("TCP", "orphan", "Number of TCP sockets not attached to a file descriptor."),
+	"TCP_tw":         newSockStatDesc("TCP", "tw", "Number of TCP sockets in TIME_WAIT."),
+	"TCP_alloc":      newSockStatDesc("TCP", "alloc", "Number of allocated TCP sockets."),
+	"TCP_mem":        newSockStatDesc("TCP", "mem", "Memory used by TCP sockets in pages."),
+	"TCP_mem_bytes":  newSockStatDesc("TCP", "mem_bytes", "Memory used by TCP sockets in bytes."),
+	"UDP_inuse":      newSockStatDesc("UDP", "inuse", "Number of UDP sockets in use."),
+	"UDP_mem":        newSockStatDesc("UDP", "mem", "Memory used by UDP sockets in pages."),
+	"UDP_mem_bytes":  newSockStatDesc("UDP", "mem_bytes", "Memory used by UDP sockets in bytes."),
+	"UDPLITE_inuse":  newSockStatDesc("UDPLITE", "inuse", "Number of UDP-Lite sockets in use."),
+	"RAW_inuse":      newSockStatDesc("RAW", "inuse", "Number of raw sockets in use."),
+	"FRAG_inuse":     newSockStatDesc("FRAG", "inuse", "Number of IPv4 fragment reassembly queues."),
+	"FRAG_memory":    newSockStatDesc("FRAG", "memory", "Memory used by IPv4 fragment reassembly in bytes."),
+	"TCP6_inuse":     newSockStatDesc("TCP6", "inuse", "Number of TCP6 sockets in use."),
+	"UDP6_inuse":     newSockStatDesc("UDP6", "inuse", "Number of UDP6 sockets in use."),
+	"UDPLITE6_inuse": newSockStatDesc("UDPLITE6", "inuse", "Number of UDP-Lite6 sockets in use."),
+	"RAW6_inuse":     newSockStatDesc("RAW6", "inuse", "Number of raw IPv6 sockets in use."),
+	"FRAG6_inuse":    newSockStatDesc("FRAG6", "inuse", "Number of IPv6 fragment reassembly queues."),
+	"FRAG6_memory":   newSockStatDesc("FRAG6", "memory", "Memory used by IPv6 fragment reassembly in bytes."),
+}
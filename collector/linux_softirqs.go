@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"flag"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(SoftIRQsCollector))
+}
+
+type SoftIRQsCollector struct{}
+
+func (collector *SoftIRQsCollector) GetName() string {
+	return "softirqs"
+}
+
+func (collector *SoftIRQsCollector) Describe(ch chan<- *prometheus.Desc) error {
+	if *softIRQsAggregate {
+		ch <- softIRQsAggregateDesc
+	} else {
+		ch <- softIRQsDesc
+	}
+	return nil
+}
+
+func (collector *SoftIRQsCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	path := "cat"
+	args := []string{"/proc/softirqs"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get softirqs metric failed, error: %s", err.Error())
+	}
+
+	stat, err := p.ParseSoftIRQs(bytes)
+	if err != nil {
+		return fmt.Errorf("parse softirqs metric failed, error: %s", err.Error())
+	}
+
+	for vector, values := range stat {
+		if *softIRQsAggregate {
+			var total uint64
+			for _, value := range values {
+				total += value
+			}
+			ch <- prometheus.MustNewConstMetric(softIRQsAggregateDesc, prometheus.CounterValue, float64(total), vector)
+			continue
+		}
+
+		for cpu, value := range values {
+			ch <- prometheus.MustNewConstMetric(softIRQsDesc, prometheus.CounterValue, float64(value), cpu, vector)
+		}
+	}
+
+	return nil
+}
+
+var (
+	softIRQsAggregate = flag.Bool(
+		"collector.softirqs.aggregate",
+		false,
+		"Sum softirqs over all CPUs instead of exporting a series per CPU.",
+	)
+
+	softIRQsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "softirqs", "total"),
+		"Number of softirqs handled per CPU and vector.",
+		[]string{"cpu", "vector"}, nil,
+	)
+
+	softIRQsAggregateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "softirqs", "total"),
+		"Number of softirqs handled per vector, summed over all CPUs.",
+		[]string{"vector"}, nil,
+	)
+)
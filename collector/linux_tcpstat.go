@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(TCPStatCollector))
+}
+
+type TCPStatCollector struct {
+	fallbackOnce sync.Once
+}
+
+func (collector *TCPStatCollector) GetName() string {
+	return "tcpstat"
+}
+
+func (collector *TCPStatCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- tcpConnectionStatesDesc
+	ch <- tcpTransmitQueuedDesc
+	ch <- tcpReceiveQueuedDesc
+	ch <- tcpPortConnectionStatesDesc
+	return nil
+}
+
+func (collector *TCPStatCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	ports, err := tcpStatPorts()
+	if err != nil {
+		return fmt.Errorf("tcpstat ports: %s", err.Error())
+	}
+
+	stat, err := p.ParseTCPStat(ports)
+	if err != nil {
+		// sock_diag may be missing or blocked, e.g. by a seccomp profile.
+		collector.fallbackOnce.Do(func() {
+			log.Println(fmt.Sprintf("[WARN] sock_diag dump failed, reading /proc/net/tcp instead, error: %s", err.Error()))
+		})
+		if stat, err = procNetTCPStat(p, ports); err != nil {
+			return err
+		}
+	}
+
+	for state, s := range stat.States {
+		ch <- prometheus.MustNewConstMetric(tcpConnectionStatesDesc, prometheus.GaugeValue, s.Count, state)
+		// The queues of listening sockets hold connections, not bytes.
+		if state == "listen" {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(tcpTransmitQueuedDesc, prometheus.GaugeValue, s.TransmitQueue, state)
+		ch <- prometheus.MustNewConstMetric(tcpReceiveQueuedDesc, prometheus.GaugeValue, s.ReceiveQueue, state)
+	}
+	for port, states := range stat.Ports {
+		for state, s := range states {
+			ch <- prometheus.MustNewConstMetric(tcpPortConnectionStatesDesc, prometheus.GaugeValue, s.Count, strconv.Itoa(int(port)), state)
+		}
+	}
+
+	return nil
+}
+
+func procNetTCPStat(p parser.Parser, ports map[uint16]bool) (parser.TCPStat, error) {
+	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/tcp")
+	if err != nil {
+		return parser.TCPStat{}, fmt.Errorf("get tcpstat metric failed, error: %s", err.Error())
+	}
+
+	stat, err := p.ParseProcNetTCP(bytes, ports)
+	if err != nil {
+		return stat, fmt.Errorf("parse tcpstat metric failed, error: %s", err.Error())
+	}
+
+	// tcp6 is missing when IPv6 is disabled.
+	if _, err := os.Stat("/proc/net/tcp6"); err == nil {
+		bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/tcp6")
+		if err != nil {
+			return stat, fmt.Errorf("get tcp6stat metric failed, error: %s", err.Error())
+		}
+
+		stat6, err := p.ParseProcNetTCP(bytes, ports)
+		if err != nil {
+			return stat, fmt.Errorf("parse tcp6stat metric failed, error: %s", err.Error())
+		}
+		stat.Merge(stat6)
+	}
+
+	return stat, nil
+}
+
+func tcpStatPorts() (map[uint16]bool, error) {
+	ports := map[uint16]bool{}
+	for _, field := range strings.Split(*tcpStatPortList, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		port, err := strconv.ParseUint(field, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", field)
+		}
+		ports[uint16(port)] = true
+	}
+	return ports, nil
+}
+
+var (
+	tcpStatPortList = flag.String(
+		"collector.tcpstat.ports",
+		"",
+		"Comma separated local ports to break down TCP connection states by, e.g. 80,443.",
+	)
+
+	tcpConnectionStatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "tcp", "connection_states"),
+		"Number of TCP sockets by connection state.",
+		[]string{"state"}, nil,
+	)
+	tcpTransmitQueuedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "tcp", "transmit_queued_bytes"),
+		"Bytes in the send queues of TCP sockets by connection state. Listening sockets are not included, their queues count pending connections.",
+		[]string{"state"}, nil,
+	)
+	tcpReceiveQueuedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "tcp", "receive_queued_bytes"),
+		"Bytes in the receive queues of TCP sockets by connection state. Listening sockets are not included, their queues count pending connections.",
+		[]string{"state"}, nil,
+	)
+	tcpPortConnectionStatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "tcp", "port_connection_states"),
+		"Number of TCP sockets by local port and connection state, for the ports of collector.tcpstat.ports.",
+		[]string{"port", "state"}, nil,
+	)
+)
@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(VMStatCollector))
+}
+
+type VMStatCollector struct{}
+
+func (collector *VMStatCollector) GetName() string {
+	return "vmstat"
+}
+
+func (collector *VMStatCollector) Describe(ch chan<- *prometheus.Desc) error {
+	return nil
+}
+
+func (collector *VMStatCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	pattern, err := vmStatFieldsPattern.regexp()
+	if err != nil {
+		return fmt.Errorf("vmstat fields: %s", err.Error())
+	}
+
+	path := "cat"
+	args := []string{"/proc/vmstat"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get vmstat metric failed, error: %s", err.Error())
+	}
+
+	stat, err := p.ParseVMStat(bytes)
+	if err != nil {
+		return fmt.Errorf("parse vmstat metric failed, error: %s", err.Error())
+	}
+
+	for key, value := range stat {
+		if !pattern.MatchString(key) {
+			continue
+		}
+
+		// nr_* fields are current counts, everything else is an event counter.
+		metricType := prometheus.CounterValue
+		if strings.HasPrefix(key, "nr_") {
+			metricType = prometheus.GaugeValue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("node", "vmstat", key),
+				fmt.Sprintf("/proc/vmstat information field %s.", key),
+				nil, nil,
+			),
+			metricType, value,
+		)
+	}
+
+	return nil
+}
+
+var (
+	vmStatFields = flag.String(
+		"collector.vmstat.fields",
+		"^(pgfault|pgmajfault|pswpin|pswpout|oom_kill)$",
+		"Regexp of fields to return for vmstat collector.",
+	)
+	vmStatFieldsPattern = newFlagRegexp(vmStatFields)
+)
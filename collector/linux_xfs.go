@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	for _, field := range xfsFields {
+		field.desc = prometheus.NewDesc(
+			prometheus.BuildFQName("node", "xfs", field.name),
+			field.help,
+			xfsLabelNames, nil,
+		)
+	}
+
+	registerCollector(new(XFSCollector))
+}
+
+type XFSCollector struct{}
+
+func (collector *XFSCollector) GetName() string {
+	return "xfs"
+}
+
+func (collector *XFSCollector) Describe(ch chan<- *prometheus.Desc) error {
+	for _, field := range xfsFields {
+		ch <- field.desc
+	}
+	return nil
+}
+
+func (collector *XFSCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	// Without the xfs module there is nothing to report.
+	if _, err := os.Stat("/proc/fs/xfs/stat"); os.IsNotExist(err) {
+		return nil
+	}
+
+	stats, err := p.ParseXFSDeviceStats()
+	if err != nil {
+		return fmt.Errorf("parse xfs device metric failed, error: %s", err.Error())
+	}
+
+	// Kernels without per-device statistics only provide the sum over all
+	// filesystems, which is reported with empty device and mountpoint labels.
+	if len(stats) == 0 {
+		bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/fs/xfs/stat")
+		if err != nil {
+			return fmt.Errorf("get xfs metric failed, error: %s", err.Error())
+		}
+		stat, err := p.ParseXFSStat(bytes)
+		if err != nil {
+			return fmt.Errorf("parse xfs metric failed, error: %s", err.Error())
+		}
+		stats = map[string]parser.XFSStat{"": stat}
+	}
+
+	mountPoints, err := mountPointsByBlockDevice(p)
+	if err != nil {
+		return fmt.Errorf("parse mountinfo failed, error: %s", err.Error())
+	}
+
+	for device, stat := range stats {
+		for _, field := range xfsFields {
+			values := stat[field.section]
+			if field.index >= len(values) {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(field.desc, prometheus.CounterValue, values[field.index],
+				device, mountPoints[device])
+		}
+	}
+
+	return nil
+}
+
+type xfsField struct {
+	// Line and position of the value in the stats file.
+	section string
+	index   int
+	name    string
+	help    string
+	desc    *prometheus.Desc
+}
+
+var (
+	xfsLabelNames = []string{"device", "mountpoint"}
+
+	xfsFields = []*xfsField{
+		{section: "extent_alloc", index: 0, name: "extent_allocation_extents_allocated_total", help: "Number of extents allocated for a filesystem."},
+		{section: "extent_alloc", index: 1, name: "extent_allocation_blocks_allocated_total", help: "Number of blocks allocated for a filesystem."},
+		{section: "extent_alloc", index: 2, name: "extent_allocation_extents_freed_total", help: "Number of extents freed for a filesystem."},
+		{section: "extent_alloc", index: 3, name: "extent_allocation_blocks_freed_total", help: "Number of blocks freed for a filesystem."},
+		{section: "blk_map", index: 0, name: "block_mapping_reads_total", help: "Number of block map for read operations for a filesystem."},
+		{section: "blk_map", index: 1, name: "block_mapping_writes_total", help: "Number of block map for write operations for a filesystem."},
+		{section: "blk_map", index: 2, name: "block_mapping_unmaps_total", help: "Number of block unmaps (deletes) for a filesystem."},
+		{section: "log", index: 0, name: "log_writes_total", help: "Number of log buffer writes to disk."},
+		{section: "log", index: 1, name: "log_blocks_total", help: "Number of 512-byte log blocks written to disk."},
+		{section: "log", index: 2, name: "log_noiclogs_total", help: "Number of times a log write had to wait for a free in-core log buffer."},
+		{section: "log", index: 3, name: "log_forces_total", help: "Number of times the in-core log was forced to disk."},
+		{section: "log", index: 4, name: "log_force_sleeps_total", help: "Number of times a log force had to wait for the write to complete."},
+		{section: "ig", index: 0, name: "inode_operation_attempts_total", help: "Number of times the inode cache was looked up."},
+		{section: "ig", index: 1, name: "inode_operation_found_total", help: "Number of times an inode was found in the inode cache."},
+		{section: "ig", index: 3, name: "inode_operation_misses_total", help: "Number of times an inode was not found in the inode cache."},
+		{section: "ig", index: 5, name: "inode_operation_reclaims_total", help: "Number of times an inode was reclaimed from the inode cache."},
+		{section: "rw", index: 0, name: "read_calls_total", help: "Number of read(2) system calls made to files in a filesystem."},
+		{section: "rw", index: 1, name: "write_calls_total", help: "Number of write(2) system calls made to files in a filesystem."},
+		{section: "xpc", index: 1, name: "write_bytes_total", help: "Number of bytes written to files in a filesystem."},
+		{section: "xpc", index: 2, name: "read_bytes_total", help: "Number of bytes read from files in a filesystem."},
+	}
+)
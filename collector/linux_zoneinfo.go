@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"context"
+	"exporter/parser"
+	"exporter/util"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector(new(ZoneInfoCollector))
+}
+
+type ZoneInfoCollector struct{}
+
+func (collector *ZoneInfoCollector) GetName() string {
+	return "zoneinfo"
+}
+
+func (collector *ZoneInfoCollector) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- zoneInfoProtectionDesc
+	return nil
+}
+
+func (collector *ZoneInfoCollector) Collect(p parser.Parser, ch chan<- prometheus.Metric) error {
+	path := "cat"
+	args := []string{"/proc/zoneinfo"}
+
+	bytes, err := util.ExecCommand(context.Background(), path, args...)
+	if err != nil {
+		return fmt.Errorf("get zoneinfo metric failed, error: %s", err.Error())
+	}
+
+	stats, err := p.ParseZoneInfo(bytes)
+	if err != nil {
+		return fmt.Errorf("parse zoneinfo metric failed, error: %s", err.Error())
+	}
+
+	metricDescs := map[string]*prometheus.Desc{}
+	for _, stat := range stats {
+		for key, value := range stat.Pages {
+			desc, ok := metricDescs[key]
+			if !ok {
+				desc = prometheus.NewDesc(
+					prometheus.BuildFQName("node", "zoneinfo", key+"_pages"),
+					fmt.Sprintf("Zone information field %s, in pages.", key),
+					zoneInfoLabelNames, nil,
+				)
+				metricDescs[key] = desc
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, stat.Node, stat.Zone)
+		}
+
+		for zone, value := range stat.Protection {
+			ch <- prometheus.MustNewConstMetric(zoneInfoProtectionDesc, prometheus.GaugeValue, value,
+				stat.Node, stat.Zone, zone)
+		}
+	}
+
+	return nil
+}
+
+var (
+	zoneInfoLabelNames = []string{"node", "zone"}
+
+	zoneInfoProtectionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("node", "zoneinfo", "protection_pages"),
+		"Pages of the zone reserved against allocations that can also be served from a higher zone.",
+		[]string{"node", "zone", "higher_zone"}, nil,
+	)
+)
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// neighbourStates names the NUD_* states of linux/neighbour.h.
+var neighbourStates = map[uint16]string{
+	unix.NUD_NONE:       "none",
+	unix.NUD_INCOMPLETE: "incomplete",
+	unix.NUD_REACHABLE:  "reachable",
+	unix.NUD_STALE:      "stale",
+	unix.NUD_DELAY:      "delay",
+	unix.NUD_PROBE:      "probe",
+	unix.NUD_FAILED:     "failed",
+	unix.NUD_NOARP:      "noarp",
+	unix.NUD_PERMANENT:  "permanent",
+}
+
+// ParseARP dumps the IPv4 neighbour table with a single RTM_GETNEIGH request.
+// Unlike /proc/net/arp it reports the state of each entry.
+func (parser *LinuxParser) ParseARP(filter NetDevFilter) ([]ARPEntry, error) {
+	names, err := netLinkNames()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := syscall.NetlinkRIB(unix.RTM_GETNEIGH, unix.AF_INET)
+	if err != nil {
+		return nil, fmt.Errorf("netlink dump: %w", err)
+	}
+	return parseNeighbourMessages(data, names, filter)
+}
+
+func parseNeighbourMessages(data []byte, names map[uint32]string, filter NetDevFilter) ([]ARPEntry, error) {
+	messages, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ARPEntry, 0, len(messages))
+	for _, message := range messages {
+		if message.Header.Type == unix.NLMSG_ERROR && len(message.Data) >= 4 {
+			errno := int32(nativeEndian.Uint32(message.Data))
+			return nil, fmt.Errorf("netlink dump: %w", syscall.Errno(-errno))
+		}
+		if message.Header.Type != unix.RTM_NEWNEIGH || len(message.Data) < unix.SizeofNdMsg {
+			continue
+		}
+
+		// struct ndmsg: family, padding, ifindex, state, flags and type.
+		entry := ARPEntry{
+			Device: names[nativeEndian.Uint32(message.Data[4:8])],
+			State:  neighbourState(nativeEndian.Uint16(message.Data[8:10])),
+		}
+		if entry.Device == "" || filter.ignored(entry.Device) {
+			continue
+		}
+
+		// syscall.ParseNetlinkRouteAttr does not know RTM_NEWNEIGH.
+		for _, attr := range parseNestedAttrs(message.Data[unix.SizeofNdMsg:]) {
+			if attr.Attr.Type == unix.NDA_DST && len(attr.Value) == net.IPv4len {
+				entry.IP = net.IP(attr.Value).String()
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func neighbourState(state uint16) string {
+	if name, ok := neighbourStates[state]; ok {
+		return name
+	}
+	return strconv.Itoa(int(state))
+}
+
+// netLinkNames maps the index of each network device to its name.
+func netLinkNames() (map[uint32]string, error) {
+	data, err := syscall.NetlinkRIB(unix.RTM_GETLINK, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("netlink dump: %w", err)
+	}
+	links, err := parseNetLinkMessages(data, NetDevFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint32]string, len(links))
+	for _, link := range links {
+		names[link.Index] = link.Name
+	}
+	return names, nil
+}
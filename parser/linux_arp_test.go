@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseNeighbourMessages(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "netlink", "neighbours"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter NetDevFilter
+		want   []ARPEntry
+	}{
+		{
+			want: []ARPEntry{
+				{IP: "192.0.2.1", Device: "eth0", State: "stale"},
+				{IP: "0.0.0.0", Device: "lo", State: "noarp"},
+			},
+		},
+		{
+			filter: NetDevFilter{Exclude: regexp.MustCompile(`^lo$`)},
+			want:   []ARPEntry{{IP: "192.0.2.1", Device: "eth0", State: "stale"}},
+		},
+	}
+
+	for _, test := range tests {
+		got, err := parseNeighbourMessages(data, netLinkTestNames, test.filter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got %+v, want %+v", got, test.want)
+		}
+	}
+}
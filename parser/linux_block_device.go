@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseBlockDevices() ([]BlockDevice, error) {
+	entries, err := ioutil.ReadDir(parser.sysFSPath("block"))
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]BlockDevice, 0, len(entries))
+	for _, entry := range entries {
+		device, err := parser.parseBlockDevice(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("block device %s: %w", entry.Name(), err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+func (parser *LinuxParser) parseBlockDevice(name string) (BlockDevice, error) {
+	dir := parser.sysFSPath("block", name)
+	device := BlockDevice{
+		Name: name,
+		// SCSI devices keep the identity in the device directory, NVMe
+		// namespaces point to their controller and virtio has a serial only.
+		Model:      readSysFileFirst(filepath.Join(dir, "device", "model")),
+		Serial:     readSysFileFirst(filepath.Join(dir, "device", "serial"), filepath.Join(dir, "serial")),
+		WWN:        readSysFileFirst(filepath.Join(dir, "wwid"), filepath.Join(dir, "device", "wwid")),
+		Revision:   readSysFileFirst(filepath.Join(dir, "device", "rev"), filepath.Join(dir, "device", "firmware_rev")),
+		Rotational: readSysFileFirst(filepath.Join(dir, "queue", "rotational")),
+		DMName:     readSysFileFirst(filepath.Join(dir, "dm", "name")),
+		Scheduler:  parseScheduler(readSysFileFirst(filepath.Join(dir, "queue", "scheduler"))),
+	}
+
+	mdArrays, err := blockDeviceHolders(dir, name)
+	if err != nil {
+		return device, err
+	}
+	device.MDArrays = mdArrays
+
+	values := []struct {
+		path  string
+		value **float64
+	}{
+		{filepath.Join(dir, "size"), &device.Size},
+		{filepath.Join(dir, "queue", "logical_block_size"), &device.LogicalBlockSize},
+		{filepath.Join(dir, "queue", "physical_block_size"), &device.PhysicalBlockSize},
+		{filepath.Join(dir, "queue", "nr_requests"), &device.NrRequests},
+		{filepath.Join(dir, "device", "queue_depth"), &device.QueueDepth},
+	}
+	for _, v := range values {
+		content := readSysFileFirst(v.path)
+		if content == "" {
+			continue
+		}
+		fv, err := strconv.ParseFloat(content, 64)
+		if err != nil {
+			return device, fmt.Errorf("invalid value %s in %s: %w", content, v.path, err)
+		}
+		*v.value = &fv
+	}
+	if device.Size != nil {
+		// The size attribute is always in 512 byte sectors.
+		size := *device.Size * diskSectorSize
+		device.Size = &size
+	}
+
+	return device, nil
+}
+
+// blockDeviceHolders returns the md arrays built on top of the device or on
+// top of one of its partitions.
+func blockDeviceHolders(dir, name string) ([]string, error) {
+	holderDirs, err := filepath.Glob(filepath.Join(dir, name+"*", "holders"))
+	if err != nil {
+		return nil, err
+	}
+	holderDirs = append(holderDirs, filepath.Join(dir, "holders"))
+
+	arrays := make([]string, 0)
+	for _, holderDir := range holderDirs {
+		holders, err := ioutil.ReadDir(holderDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, holder := range holders {
+			if strings.HasPrefix(holder.Name(), "md") {
+				arrays = append(arrays, holder.Name())
+			}
+		}
+	}
+	sort.Strings(arrays)
+
+	return arrays, nil
+}
+
+// parseScheduler returns the active scheduler of a "none [mq-deadline] kyber" list.
+func parseScheduler(schedulers string) string {
+	for _, scheduler := range strings.Fields(schedulers) {
+		if strings.HasPrefix(scheduler, "[") && strings.HasSuffix(scheduler, "]") {
+			return strings.Trim(scheduler, "[]")
+		}
+	}
+	return schedulers
+}
+
+// readSysFileFirst returns the trimmed content of the first readable file,
+// or an empty string if none of them exist.
+func readSysFileFirst(paths ...string) string {
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return strings.TrimSpace(string(content))
+	}
+	return ""
+}
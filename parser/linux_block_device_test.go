@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseBlockDevices(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices, err := parser.ParseBlockDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]BlockDevice{}
+	for _, device := range devices {
+		got[device.Name] = device
+	}
+	if len(got) != 4 {
+		t.Fatalf("want 4 block devices, got %+v", devices)
+	}
+
+	sda := got["sda"]
+	if sda.Model != "Samsung SSD 860" || sda.Serial != "S3Z9NB0K" || sda.Revision != "4B6Q" ||
+		sda.Rotational != "0" || sda.Scheduler != "mq-deadline" {
+		t.Errorf("unexpected sda identity: %+v", sda)
+	}
+	if sda.Size == nil || *sda.Size != 1953525168*512 {
+		t.Errorf("want sda size in bytes, got %v", sda.Size)
+	}
+	if sda.PhysicalBlockSize == nil || *sda.PhysicalBlockSize != 4096 || sda.QueueDepth != nil {
+		t.Errorf("unexpected sda queue attributes: %+v", sda)
+	}
+
+	if got["dm-0"].DMName != "vg0-root" {
+		t.Errorf("want dm name vg0-root, got %q", got["dm-0"].DMName)
+	}
+
+	// sda is a member through its partition, sdb as a whole disk. The dm
+	// holder of sda1 is not an md array.
+	wantArrays := map[string][]string{
+		"sda":  {"md0"},
+		"sdb":  {"md0"},
+		"dm-0": {},
+		"md0":  {},
+	}
+	for name, want := range wantArrays {
+		if !reflect.DeepEqual(got[name].MDArrays, want) {
+			t.Errorf("%s: want md arrays %v, got %v", name, want, got[name].MDArrays)
+		}
+	}
+}
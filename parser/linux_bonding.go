@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseBonding(filter NetDevFilter) ([]Bond, error) {
+	dirs, err := filepath.Glob(parser.sysFSPath("class", "net", "*", "bonding"))
+	if err != nil {
+		return nil, err
+	}
+
+	bonds := make([]Bond, 0, len(dirs))
+	for _, dir := range dirs {
+		name := filepath.Base(filepath.Dir(dir))
+		if filter.ignored(name) {
+			continue
+		}
+
+		bond := Bond{
+			Name: name,
+			// "802.3ad 4", keep the name only.
+			Mode:      firstField(readSysFileFirst(filepath.Join(dir, "mode"))),
+			MIIStatus: readSysFileFirst(filepath.Join(dir, "mii_status")),
+		}
+
+		for _, slaveName := range strings.Fields(readSysFileFirst(filepath.Join(dir, "slaves"))) {
+			slaveDir := parser.sysFSPath("class", "net", slaveName, "bonding_slave")
+			slave := BondSlave{
+				Name:      slaveName,
+				MIIStatus: readSysFileFirst(filepath.Join(slaveDir, "mii_status")),
+				State:     readSysFileFirst(filepath.Join(slaveDir, "state")),
+			}
+
+			if content := readSysFileFirst(filepath.Join(slaveDir, "link_failure_count")); content != "" {
+				count, err := strconv.ParseFloat(content, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid link_failure_count %s of %s: %w", content, slaveName, err)
+				}
+				slave.LinkFailures = &count
+			}
+			bond.Slaves = append(bond.Slaves, slave)
+		}
+		bonds = append(bonds, bond)
+	}
+
+	return bonds, nil
+}
+
+func firstField(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
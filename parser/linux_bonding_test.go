@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseBonding(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.ParseBonding(NetDevFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zero, three := 0.0, 3.0
+	want := []Bond{{
+		Name:      "bond0",
+		Mode:      "802.3ad",
+		MIIStatus: "up",
+		Slaves: []BondSlave{
+			{Name: "eth1", MIIStatus: "up", State: "active", LinkFailures: &zero},
+			{Name: "eth2", MIIStatus: "down", State: "backup", LinkFailures: &three},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	got, err = parser.ParseBonding(NetDevFilter{Exclude: regexp.MustCompile(`^bond`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("want no bonds, got %+v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// BridgePortStates are the STP port states of linux/if_bridge.h in order of
+// their value.
+var BridgePortStates = []string{"disabled", "listening", "learning", "forwarding", "blocking"}
+
+func (parser *LinuxParser) ParseBridges(filter NetDevFilter) ([]Bridge, error) {
+	dirs, err := filepath.Glob(parser.sysFSPath("class", "net", "*", "bridge"))
+	if err != nil {
+		return nil, err
+	}
+
+	bridges := make([]Bridge, 0, len(dirs))
+	for _, dir := range dirs {
+		devicePath := filepath.Dir(dir)
+		name := filepath.Base(devicePath)
+		if filter.ignored(name) {
+			continue
+		}
+
+		bridge := Bridge{Name: name}
+		// 0 without STP, 1 for kernel STP, 2 for a user space daemon.
+		if stpState, err := strconv.Atoi(readSysFileFirst(filepath.Join(dir, "stp_state"))); err == nil {
+			enabled := stpState != 0
+			bridge.STPEnabled = &enabled
+		}
+
+		ports, err := ioutil.ReadDir(filepath.Join(devicePath, "brif"))
+		// The bridge was deleted since it was listed.
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, port := range ports {
+			state := readSysFileFirst(filepath.Join(devicePath, "brif", port.Name(), "state"))
+			if i, err := strconv.Atoi(state); err == nil && i >= 0 && i < len(BridgePortStates) {
+				state = BridgePortStates[i]
+			}
+			bridge.Ports = append(bridge.Ports, BridgePort{Name: port.Name(), State: state})
+		}
+		bridges = append(bridges, bridge)
+	}
+
+	return bridges, nil
+}
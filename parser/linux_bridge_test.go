@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseBridges(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.ParseBridges(NetDevFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// br1 has no stp_state and its port an unknown state, br2 lost its brif
+	// directory as if it was deleted during the scrape.
+	enabled := true
+	want := []Bridge{
+		{
+			Name:       "br0",
+			STPEnabled: &enabled,
+			Ports: []BridgePort{
+				{Name: "eth3", State: "forwarding"},
+				{Name: "vnet0", State: "blocking"},
+			},
+		},
+		{
+			Name:  "br1",
+			Ports: []BridgePort{{Name: "veth0", State: "7"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
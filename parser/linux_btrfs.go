@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/procfs/btrfs"
+)
+
+func (parser *LinuxParser) ParseBtrfsStats() ([]BtrfsStat, error) {
+	fs, err := btrfs.NewFS(parser.sysMountPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := fs.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	btrfsStats := make([]BtrfsStat, 0, len(stats))
+	for _, stat := range stats {
+		deviceErrors, err := parser.parseBtrfsDeviceErrors(stat.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("btrfs %s: %w", stat.UUID, err)
+		}
+		btrfsStats = append(btrfsStats, BtrfsStat{Stats: stat, DeviceErrors: deviceErrors})
+	}
+
+	return btrfsStats, nil
+}
+
+// parseBtrfsDeviceErrors reads devinfo/<devid>/error_stats, which kernels
+// before 5.14 do not provide.
+func (parser *LinuxParser) parseBtrfsDeviceErrors(uuid string) (map[string]map[string]float64, error) {
+	files, err := filepath.Glob(parser.sysFSPath("fs", "btrfs", uuid, "devinfo", "*", "error_stats"))
+	if err != nil {
+		return nil, err
+	}
+
+	deviceErrors := make(map[string]map[string]float64, len(files))
+	for _, file := range files {
+		bytesData, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		errors := map[string]float64{}
+		scanner := bufio.NewScanner(bytes.NewBuffer(bytesData))
+		for scanner.Scan() {
+			// write_errs 0
+			parts := strings.Fields(scanner.Text())
+			if len(parts) != 2 {
+				continue
+			}
+			value, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s in %s: %w", parts[1], file, err)
+			}
+			errors[strings.TrimSuffix(parts[0], "_errs")] = value
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		devID := filepath.Base(filepath.Dir(file))
+		deviceErrors[devID] = errors
+	}
+
+	return deviceErrors, nil
+}
@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseBtrfsDeviceErrors(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.parseBtrfsDeviceErrors("0abb23a9-579b-43e6-ad30-227ef47fcb9d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string]float64{
+		"1": {"write": 0, "read": 3, "flush": 0, "corruption": 1, "generation": 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
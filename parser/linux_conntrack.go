@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ParseConntrack reads the number of tracked connections and the size of
+// the conntrack table from /proc/sys/net/netfilter.
+func (parser *LinuxParser) ParseConntrack() (Conntrack, error) {
+	var (
+		conntrack Conntrack
+		err       error
+	)
+	if conntrack.Entries, err = readConntrackValue("nf_conntrack_count"); err != nil {
+		return conntrack, err
+	}
+	if conntrack.EntriesLimit, err = readConntrackValue("nf_conntrack_max"); err != nil {
+		return conntrack, err
+	}
+	return conntrack, nil
+}
+
+func readConntrackValue(name string) (float64, error) {
+	content, err := ioutil.ReadFile(procFilePath("sys/net/netfilter/" + name))
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// ParseConntrackStat parses /proc/net/stat/nf_conntrack, a header naming the
+// fields followed by one line of hexadecimal values per CPU.
+func (parser *LinuxParser) ParseConntrackStat(bytesData []byte) ([]ConntrackStat, error) {
+	var (
+		stats   = make([]ConntrackStat, 0)
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+		header  []string
+	)
+
+	if scanner.Scan() {
+		header = strings.Fields(scanner.Text())
+	}
+	for cpu := 0; scanner.Scan(); cpu++ {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != len(header) {
+			return nil, fmt.Errorf("invalid line in nf_conntrack stat: %s", scanner.Text())
+		}
+
+		stat := ConntrackStat{CPU: strconv.Itoa(cpu), Values: make(map[string]float64, len(parts))}
+		for i, part := range parts {
+			value, err := strconv.ParseUint(part, 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s of %s in nf_conntrack stat: %w", part, header[i], err)
+			}
+			stat.Values[header[i]] = float64(value)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, scanner.Err()
+}
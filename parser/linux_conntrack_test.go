@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseConntrackStat(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "conntrack", "linux-5.10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := &LinuxParser{}
+	stats, err := parser.ParseConntrackStat(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("want 2 CPUs, got %d", len(stats))
+	}
+
+	tests := []struct {
+		cpu   int
+		field string
+		want  float64
+	}{
+		{0, "invalid", 43},
+		{0, "ignore", 189607},
+		{0, "search_restart", 1},
+		{1, "found", 3},
+		{1, "insert_failed", 4},
+		{1, "drop", 5},
+		{1, "early_drop", 1},
+		{1, "search_restart", 10},
+	}
+	for _, test := range tests {
+		if got := stats[test.cpu].Values[test.field]; got != test.want {
+			t.Errorf("cpu %d %s: want %v, got %v", test.cpu, test.field, test.want, got)
+		}
+	}
+	if stats[1].CPU != "1" {
+		t.Errorf("want cpu 1, got %s", stats[1].CPU)
+	}
+}
+
+func TestParseConntrackStatInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	for _, data := range []string{"entries found\n00000001\n", "entries found\n00000001 zz\n"} {
+		if _, err := parser.ParseConntrackStat([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
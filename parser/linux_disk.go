@@ -4,23 +4,97 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
+const (
+	// diskSectorSize is the unit of the sector columns of /proc/diskstats,
+	// which is always 512 bytes regardless of the device.
+	diskSectorSize   = 512.0
+	diskMilliseconds = 1000.0
+)
+
 func (parser *LinuxParser) ParseDiskStat(bytesData []byte) (DiskStat, error) {
 	var (
-		diskInfo = map[string][]string{}
+		diskInfo = DiskStat{}
 		scanner  = bufio.NewScanner(bytes.NewBuffer(bytesData))
 	)
 
 	for scanner.Scan() {
 		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
 		if len(parts) < 4 {
 			return nil, fmt.Errorf("invalid line: %s", parts)
 		}
 		dev := parts[2]
-		diskInfo[dev] = parts[3:]
+
+		stat, err := parseDiskDeviceStat(parts[3:])
+		if err != nil {
+			return nil, fmt.Errorf("device %s: %w", dev, err)
+		}
+		diskInfo[dev] = stat
 	}
 
 	return diskInfo, scanner.Err()
 }
+
+// parseDiskDeviceStat converts the statistic columns of a diskstats line.
+// Depending on the kernel there are 4 (partitions before 2.6.25), 11, 15
+// (4.18+, discards) or 17 (5.5+, flushes) of them.
+func parseDiskDeviceStat(parts []string) (DiskDeviceStat, error) {
+	stat := DiskDeviceStat{Fields: len(parts)}
+	switch {
+	case len(parts) == 4, len(parts) == 11, len(parts) == 15, len(parts) == 17:
+	case len(parts) > 17:
+		// Newer kernels may append columns, keep the ones we know.
+		stat.Fields = 17
+	default:
+		return stat, fmt.Errorf("unexpected number of fields: %d", len(parts))
+	}
+
+	values := make([]float64, stat.Fields)
+	for i := range values {
+		v, err := strconv.ParseFloat(parts[i], 64)
+		if err != nil {
+			return stat, fmt.Errorf("invalid value %s: %w", parts[i], err)
+		}
+		values[i] = v
+	}
+
+	if stat.Fields == 4 {
+		stat.ReadsCompleted = values[0]
+		stat.ReadBytes = values[1] * diskSectorSize
+		stat.WritesCompleted = values[2]
+		stat.WrittenBytes = values[3] * diskSectorSize
+		return stat, nil
+	}
+
+	stat.ReadsCompleted = values[0]
+	stat.ReadsMerged = values[1]
+	stat.ReadBytes = values[2] * diskSectorSize
+	stat.ReadTimeSeconds = values[3] / diskMilliseconds
+	stat.WritesCompleted = values[4]
+	stat.WritesMerged = values[5]
+	stat.WrittenBytes = values[6] * diskSectorSize
+	stat.WriteTimeSeconds = values[7] / diskMilliseconds
+	stat.IONow = values[8]
+	stat.IOTimeSeconds = values[9] / diskMilliseconds
+	stat.IOTimeWeightedSeconds = values[10] / diskMilliseconds
+
+	if stat.Fields >= 15 {
+		stat.DiscardsCompleted = values[11]
+		stat.DiscardsMerged = values[12]
+		stat.DiscardedSectors = values[13]
+		stat.DiscardTimeSeconds = values[14] / diskMilliseconds
+	}
+
+	if stat.Fields >= 17 {
+		stat.FlushRequests = values[15]
+		stat.FlushRequestsTimeSeconds = values[16] / diskMilliseconds
+	}
+
+	return stat, nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDiskStat(t *testing.T) {
+	tests := []struct {
+		fixture string
+		device  string
+		want    DiskDeviceStat
+	}{
+		{
+			fixture: "linux-2.6",
+			device:  "sda1",
+			want: DiskDeviceStat{
+				Fields:          4,
+				ReadsCompleted:  250,
+				ReadBytes:       1000 * 512,
+				WritesCompleted: 2,
+				WrittenBytes:    16 * 512,
+			},
+		},
+		{
+			fixture: "linux-4.17",
+			device:  "sda",
+			want: DiskDeviceStat{
+				Fields:                11,
+				ReadsCompleted:        25354637,
+				ReadsMerged:           34367663,
+				ReadBytes:             1003346126 * 512,
+				ReadTimeSeconds:       18492.372,
+				WritesCompleted:       28444756,
+				WritesMerged:          11134226,
+				WrittenBytes:          505697032 * 512,
+				WriteTimeSeconds:      63877.96,
+				IONow:                 0,
+				IOTimeSeconds:         9653.88,
+				IOTimeWeightedSeconds: 82621.804,
+			},
+		},
+		{
+			fixture: "linux-4.18",
+			device:  "nvme0n1",
+			want: DiskDeviceStat{
+				Fields:                15,
+				ReadsCompleted:        47114,
+				ReadsMerged:           4,
+				ReadBytes:             4643973 * 512,
+				ReadTimeSeconds:       21.65,
+				WritesCompleted:       1078320,
+				WritesMerged:          43950,
+				WrittenBytes:          39451633 * 512,
+				WriteTimeSeconds:      1011.053,
+				IOTimeSeconds:         222.766,
+				IOTimeWeightedSeconds: 1032.546,
+				DiscardsCompleted:     1,
+				DiscardsMerged:        2,
+				DiscardedSectors:      3,
+				DiscardTimeSeconds:    0.004,
+			},
+		},
+		{
+			fixture: "linux-5.5",
+			device:  "nvme0n1",
+			want: DiskDeviceStat{
+				Fields:                   17,
+				ReadsCompleted:           47114,
+				ReadsMerged:              4,
+				ReadBytes:                4643973 * 512,
+				ReadTimeSeconds:          21.65,
+				WritesCompleted:          1078320,
+				WritesMerged:             43950,
+				WrittenBytes:             39451633 * 512,
+				WriteTimeSeconds:         1011.053,
+				IOTimeSeconds:            222.766,
+				IOTimeWeightedSeconds:    1032.546,
+				DiscardsCompleted:        1,
+				DiscardsMerged:           2,
+				DiscardedSectors:         3,
+				DiscardTimeSeconds:       0.004,
+				FlushRequests:            71,
+				FlushRequestsTimeSeconds: 0.011,
+			},
+		},
+	}
+
+	parser := &LinuxParser{}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "diskstats", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stat, err := parser.ParseDiskStat(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := stat[test.device]
+			if !ok {
+				t.Fatalf("device %s not found", test.device)
+			}
+			if got != test.want {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDiskStatInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	if _, err := parser.ParseDiskStat([]byte("8 0 sda 1 2 3 4 5 6\n")); err == nil {
+		t.Error("expected an error for 6 statistic columns")
+	}
+}
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ETH_SS_STATS and ETH_GSTRING_LEN from linux/ethtool.h.
+	ethtoolStringSetStats = 1
+	ethtoolStringLength   = 32
+	// SPEED_UNKNOWN as returned in the 32 bit speed of ethtool_cmd, older
+	// drivers set the lower 16 bits only.
+	ethtoolSpeedUnknown   = 0xffffffff
+	ethtoolSpeedUnknown16 = 0xffff
+	// SUPPORTED_Autoneg bit of ethtool_cmd.supported.
+	ethtoolSupportedAutoneg = 1 << 6
+	ethtoolAutonegEnable    = 0x01
+)
+
+// ethtoolLinkModes names the link mode bits of the legacy 32 bit
+// ethtool_cmd.supported mask. Bits that are not a speed and duplex, such as
+// ports and pause frames, are left empty.
+var ethtoolLinkModes = [32]string{
+	0:  "10baseT/Half",
+	1:  "10baseT/Full",
+	2:  "100baseT/Half",
+	3:  "100baseT/Full",
+	4:  "1000baseT/Half",
+	5:  "1000baseT/Full",
+	12: "10000baseT/Full",
+	15: "2500baseX/Full",
+	17: "1000baseKX/Full",
+	18: "10000baseKX4/Full",
+	19: "10000baseKR/Full",
+	21: "20000baseMLD2/Full",
+	22: "20000baseKR2/Full",
+	23: "40000baseKR4/Full",
+	24: "40000baseCR4/Full",
+	25: "40000baseSR4/Full",
+	26: "40000baseLR4/Full",
+	27: "56000baseKR4/Full",
+	28: "56000baseCR4/Full",
+	29: "56000baseSR4/Full",
+	30: "56000baseLR4/Full",
+	31: "25000baseCR/Full",
+}
+
+// ethtoolCmd mirrors struct ethtool_cmd of linux/ethtool.h.
+type ethtoolCmd struct {
+	Cmd           uint32
+	Supported     uint32
+	Advertising   uint32
+	Speed         uint16
+	Duplex        uint8
+	Port          uint8
+	PhyAddress    uint8
+	Transceiver   uint8
+	Autoneg       uint8
+	MDIOSupport   uint8
+	MaxTxPkt      uint32
+	MaxRxPkt      uint32
+	SpeedHi       uint16
+	EthTpMDIX     uint8
+	EthTpMDIXCtrl uint8
+	LpAdvertising uint32
+	Reserved      [2]uint32
+}
+
+type ethtoolIfreq struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+}
+
+// ParseEthtool queries driver information, driver statistics and link
+// settings of the network devices through the SIOCETHTOOL ioctl. Requests a
+// device does not support leave the matching fields empty.
+func (parser *LinuxParser) ParseEthtool(filter NetDevFilter) ([]Ethtool, error) {
+	devices, err := parser.fs.NetClassDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ethtool socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	stats := make([]Ethtool, 0, len(devices))
+	for _, device := range devices {
+		if filter.ignored(device) || len(device) >= unix.IFNAMSIZ {
+			continue
+		}
+
+		stat := Ethtool{Device: device}
+		drvinfo, err := unix.IoctlGetEthtoolDrvinfo(fd, device)
+		if err != nil && !ethtoolUnsupported(err) {
+			return nil, fmt.Errorf("ethtool driver info of %s: %w", device, err)
+		}
+		if err == nil {
+			stat.DriverInfo = &EthtoolDriverInfo{
+				Driver:          unix.ByteSliceToString(drvinfo.Driver[:]),
+				Version:         unix.ByteSliceToString(drvinfo.Version[:]),
+				FirmwareVersion: unix.ByteSliceToString(drvinfo.Fw_version[:]),
+				BusInfo:         unix.ByteSliceToString(drvinfo.Bus_info[:]),
+			}
+
+			if drvinfo.N_stats > 0 {
+				stat.Stats, err = ethtoolStats(fd, device, drvinfo.N_stats)
+				if err != nil && !ethtoolUnsupported(err) {
+					return nil, fmt.Errorf("ethtool statistics of %s: %w", device, err)
+				}
+			}
+		}
+
+		stat.Link, err = ethtoolLink(fd, device)
+		if err != nil && !ethtoolUnsupported(err) {
+			return nil, fmt.Errorf("ethtool link settings of %s: %w", device, err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// ethtoolUnsupported reports whether the device does not implement a request
+// or disappeared since it was listed.
+func ethtoolUnsupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.ENODEV) || errors.Is(err, unix.EINVAL)
+}
+
+func ethtoolIoctl(fd int, device string, data unsafe.Pointer) error {
+	ifreq := ethtoolIfreq{data: data}
+	copy(ifreq.name[:], device)
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifreq)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// ethtoolStats reads the names of the driver statistics with ETHTOOL_GSTRINGS
+// and their values with ETHTOOL_GSTATS.
+func ethtoolStats(fd int, device string, count uint32) (map[string]uint64, error) {
+	// struct ethtool_gstrings: cmd, string_set, len, then the names.
+	names := make([]byte, 12+int(count)*ethtoolStringLength)
+	nativeEndian.PutUint32(names[0:], unix.ETHTOOL_GSTRINGS)
+	nativeEndian.PutUint32(names[4:], ethtoolStringSetStats)
+	nativeEndian.PutUint32(names[8:], count)
+	if err := ethtoolIoctl(fd, device, unsafe.Pointer(&names[0])); err != nil {
+		return nil, err
+	}
+
+	// struct ethtool_stats: cmd, n_stats, then the 64 bit values.
+	values := make([]byte, 8+int(count)*8)
+	nativeEndian.PutUint32(values[0:], unix.ETHTOOL_GSTATS)
+	nativeEndian.PutUint32(values[4:], count)
+	if err := ethtoolIoctl(fd, device, unsafe.Pointer(&values[0])); err != nil {
+		return nil, err
+	}
+
+	// The driver may return fewer statistics than it announced.
+	if n := nativeEndian.Uint32(values[4:]); n < count {
+		count = n
+	}
+
+	stats := make(map[string]uint64, count)
+	for i := 0; i < int(count); i++ {
+		offset := 12 + i*ethtoolStringLength
+		name := unix.ByteSliceToString(names[offset : offset+ethtoolStringLength])
+		stats[name] = nativeEndian.Uint64(values[8+i*8:])
+	}
+	return stats, nil
+}
+
+// ethtoolLink reads the link settings with the legacy ETHTOOL_GSET, which
+// every driver implementing link settings still answers.
+func ethtoolLink(fd int, device string) (*EthtoolLink, error) {
+	cmd := ethtoolCmd{Cmd: unix.ETHTOOL_GSET}
+	if err := ethtoolIoctl(fd, device, unsafe.Pointer(&cmd)); err != nil {
+		return nil, err
+	}
+
+	link := &EthtoolLink{
+		Autoneg:          cmd.Autoneg == ethtoolAutonegEnable,
+		SupportedAutoneg: cmd.Supported&ethtoolSupportedAutoneg != 0,
+	}
+
+	if speed := uint32(cmd.SpeedHi)<<16 | uint32(cmd.Speed); speed != ethtoolSpeedUnknown && speed != ethtoolSpeedUnknown16 {
+		// Megabits per second.
+		bytesPerSecond := float64(speed) * 1000 * 1000 / 8
+		link.Speed = &bytesPerSecond
+	}
+
+	for bit, mode := range ethtoolLinkModes {
+		if mode != "" && cmd.Supported&(1<<bit) != 0 {
+			link.SupportedModes = append(link.SupportedModes, mode)
+		}
+	}
+
+	return link, nil
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	ext4Fields = []string{
+		"errors_count",
+		"warning_count",
+		"msg_count",
+		"first_error_time",
+		"last_error_time",
+	}
+)
+
+func (parser *LinuxParser) ParseExt4Stats() ([]Ext4Stat, error) {
+	// /sys/fs/ext4 also holds a "features" directory, devices have errors_count.
+	files, err := filepath.Glob(parser.sysFSPath("fs", "ext4", "*", "errors_count"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]Ext4Stat, 0, len(files))
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		stat := Ext4Stat{
+			Device: filepath.Base(dir),
+			Values: map[string]float64{},
+		}
+
+		for _, field := range ext4Fields {
+			bytesData, err := ioutil.ReadFile(filepath.Join(dir, field))
+			if err != nil {
+				// Older kernels lack some of the counters.
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(string(bytesData)), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %s of ext4 device %s: %w", field, stat.Device, err)
+			}
+			stat.Values[field] = value
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseExt4Stats(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.ParseExt4Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The features directory has no errors_count and is not a device.
+	want := []Ext4Stat{{
+		Device: "sda1",
+		Values: map[string]float64{
+			"errors_count":     2,
+			"warning_count":    0,
+			"msg_count":        5,
+			"first_error_time": 1697712000,
+			"last_error_time":  1697798400,
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -15,102 +16,139 @@ import (
 )
 
 const (
-	rootfsPath             = "/"
-	defMountPointsExcluded = "^/(dev|proc|sys|var/lib/docker/.+)($|/)"
-	defFSTypesExcluded     = "^(autofs|binfmt_misc|bpf|cgroup2?|configfs|debugfs|devpts|devtmpfs|fusectl|hugetlbfs|iso9660|mqueue|nsfs|overlay|proc|procfs|pstore|rpc_pipefs|securityfs|selinuxfs|squashfs|sysfs|tracefs)$"
+	rootfsPath = "/"
 )
 
 var (
-	excludedMountPointsPattern = regexp.MustCompile(defMountPointsExcluded)
-	excludedFSTypesPattern     = regexp.MustCompile(defFSTypesExcluded)
-	mountTimeout               = 5
-	stuckMounts                = make(map[string]struct{})
-	stuckMountsMtx             = &sync.Mutex{}
+	stuckMounts    = make(map[string]struct{})
+	stuckMountsMtx = &sync.Mutex{}
 )
 
-func (parser *LinuxParser) ParseFileSystemStat(bytes []byte) ([]FileSystemStat, error) {
-	mps, err := parseFilesystemLabels(bytes)
+func (parser *LinuxParser) ParseFileSystemStat(bytes []byte, options FileSystemOptions) ([]FileSystemStat, error) {
+	selected, err := parser.ParseFileSystemMounts(bytes, options)
 	if err != nil {
 		return nil, err
 	}
 
-	stats := make([]FileSystemStat, 0)
+	workers := options.StatWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		stats = make([]FileSystemStat, len(selected))
+		jobs  = make(chan int)
+		wg    sync.WaitGroup
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				stats[index] = statFileSystem(selected[index], options.MountTimeout)
+			}
+		}()
+	}
+	for index := range selected {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	return stats, nil
+}
+
+// ParseFileSystemMounts returns the mounts of a mounts file that pass the
+// mount point and filesystem type patterns of options, in file order.
+func (parser *LinuxParser) ParseFileSystemMounts(bytes []byte, options FileSystemOptions) ([]FileSystemLabels, error) {
+	mps, err := parseFilesystemLabels(bytes)
+	if err != nil {
+		return nil, err
+	}
 
+	selected := make([]FileSystemLabels, 0, len(mps))
 	for _, labels := range mps {
-		if excludedMountPointsPattern.MatchString(labels.MountPoint) {
+		if ignoredByPatterns(labels.MountPoint, options.MountPointsInclude, options.MountPointsExclude) {
 			continue
 		}
-		if excludedFSTypesPattern.MatchString(labels.FsType) {
+		if ignoredByPatterns(labels.FsType, options.FSTypesInclude, options.FSTypesExclude) {
 			continue
 		}
+		selected = append(selected, labels)
+	}
+	return selected, nil
+}
 
-		stuckMountsMtx.Lock()
-
-		if _, ok := stuckMounts[labels.MountPoint]; ok {
-			stats = append(stats, FileSystemStat{
-				Labels:      labels,
-				DeviceError: 1,
-			})
-			log.Println("[ERRPR] Mount point is in an unresponsive state, mountpoint: " + labels.MountPoint)
-			stuckMountsMtx.Unlock()
-			continue
-		}
+func statFileSystem(labels FileSystemLabels, mountTimeout time.Duration) FileSystemStat {
+	stuckMountsMtx.Lock()
+	if _, ok := stuckMounts[labels.MountPoint]; ok {
 		stuckMountsMtx.Unlock()
-
-		success := make(chan struct{})
-		go stuckMountWatcher(labels.MountPoint, success)
-
-		buf := new(unix.Statfs_t)
-		err = unix.Statfs(rootfsFilePath(labels.MountPoint), buf)
-		stuckMountsMtx.Lock()
-		close(success)
-		// If the mount has been marked as stuck, unmark it and log it's recovery.
-		if _, ok := stuckMounts[labels.MountPoint]; ok {
-			log.Println("Mount point has recovered, monitoring will resume", "mountpoint", labels.MountPoint)
-			delete(stuckMounts, labels.MountPoint)
+		log.Println("[ERROR] Mount point is in an unresponsive state, mountpoint: " + labels.MountPoint)
+		return FileSystemStat{
+			Labels:      labels,
+			DeviceError: 1,
 		}
-		stuckMountsMtx.Unlock()
-
-		if err != nil {
-			stats = append(stats, FileSystemStat{
-				Labels:      labels,
-				DeviceError: 1,
-			})
+	}
+	stuckMountsMtx.Unlock()
+
+	success := make(chan struct{})
+	go stuckMountWatcher(labels.MountPoint, mountTimeout, success)
+
+	buf := new(unix.Statfs_t)
+	err := unix.Statfs(rootfsFilePath(labels.MountPoint), buf)
+	stuckMountsMtx.Lock()
+	close(success)
+	// If the mount has been marked as stuck, unmark it and log it's recovery.
+	if _, ok := stuckMounts[labels.MountPoint]; ok {
+		log.Println("Mount point has recovered, monitoring will resume", "mountpoint", labels.MountPoint)
+		delete(stuckMounts, labels.MountPoint)
+	}
+	stuckMountsMtx.Unlock()
 
-			log.Println("Error on statfs() system call, rootfs:" + labels.MountPoint)
-			continue
+	if err != nil {
+		log.Println("Error on statfs() system call, rootfs:" + labels.MountPoint)
+		return FileSystemStat{
+			Labels:      labels,
+			DeviceError: 1,
 		}
+	}
 
-		var ro float64
-		for _, option := range strings.Split(labels.Options, ",") {
-			if option == "ro" {
-				ro = 1
-				break
-			}
+	var ro float64
+	for _, option := range strings.Split(labels.Options, ",") {
+		if option == "ro" {
+			ro = 1
+			break
 		}
+	}
 
-		stats = append(stats, FileSystemStat{
-			Labels:    labels,
-			Size:      float64(buf.Blocks) * float64(buf.Bsize),
-			Free:      float64(buf.Bfree) * float64(buf.Bsize),
-			Avail:     float64(buf.Bavail) * float64(buf.Bsize),
-			Files:     float64(buf.Files),
-			FilesFree: float64(buf.Ffree),
-			Ro:        ro,
-		})
+	return FileSystemStat{
+		Labels:    labels,
+		Size:      float64(buf.Blocks) * float64(buf.Bsize),
+		Free:      float64(buf.Bfree) * float64(buf.Bsize),
+		Avail:     float64(buf.Bavail) * float64(buf.Bsize),
+		Files:     float64(buf.Files),
+		FilesFree: float64(buf.Ffree),
+		Ro:        ro,
+	}
+}
 
+// ignoredByPatterns reports whether name is rejected by an optional pair of
+// include and exclude patterns, a nil pattern does not filter.
+func ignoredByPatterns(name string, include, exclude *regexp.Regexp) bool {
+	if include != nil && !include.MatchString(name) {
+		return true
 	}
-	return stats, nil
+	return exclude != nil && exclude.MatchString(name)
 }
 
 // stuckMountWatcher listens on the given success channel and if the channel closes
 // then the watcher does nothing. If instead the timeout is reached, the
 // mount point that is being watched is marked as stuck.
-func stuckMountWatcher(mountPoint string, success chan struct{}) {
+func stuckMountWatcher(mountPoint string, mountTimeout time.Duration, success chan struct{}) {
 	select {
 	case <-success:
 		// Success
-	case <-time.After(time.Duration(mountTimeout)):
+	case <-time.After(mountTimeout):
 		// Timed out, mark mount as stuck
 		stuckMountsMtx.Lock()
 		select {
@@ -135,14 +173,9 @@ func parseFilesystemLabels(bytesData []byte) ([]FileSystemLabels, error) {
 			return nil, fmt.Errorf("malformed mount point information: %q", scanner.Text())
 		}
 
-		// Ensure we handle the translation of \040 and \011
-		// as per fstab(5).
-		parts[1] = strings.Replace(parts[1], "\\040", " ", -1)
-		parts[1] = strings.Replace(parts[1], "\\011", "\t", -1)
-
 		filesystems = append(filesystems, FileSystemLabels{
 			Device:     parts[0],
-			MountPoint: rootfsStripPrefix(parts[1]),
+			MountPoint: rootfsStripPrefix(unescapeMountPath(parts[1])),
 			FsType:     parts[2],
 			Options:    parts[3],
 		})
@@ -165,3 +198,54 @@ func rootfsStripPrefix(path string) string {
 func rootfsFilePath(name string) string {
 	return filepath.Join(rootfsPath, name)
 }
+
+func (parser *LinuxParser) ParseMountInfo(bytesData []byte) ([]MountInfo, error) {
+	var (
+		mounts  = make([]MountInfo, 0)
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		separator := -1
+		for i := 6; i < len(parts); i++ {
+			if parts[i] == "-" {
+				separator = i
+				break
+			}
+		}
+		if len(parts) < 10 || separator < 0 || len(parts) < separator+4 {
+			return nil, fmt.Errorf("malformed mountinfo line: %q", line)
+		}
+
+		mount := MountInfo{
+			MountID:     parts[0],
+			ParentID:    parts[1],
+			MajorMinor:  parts[2],
+			Root:        parts[3],
+			MountPoint:  rootfsStripPrefix(unescapeMountPath(parts[4])),
+			Options:     parts[5],
+			Propagation: strings.Join(parts[6:separator], " "),
+			FsType:      parts[separator+1],
+			Source:      parts[separator+2],
+		}
+		// Only block backed filesystems resolve to a device in /sys/dev/block.
+		if target, err := os.Readlink(parser.sysFSPath("dev", "block", mount.MajorMinor)); err == nil {
+			mount.BlockDevice = filepath.Base(target)
+		}
+		mounts = append(mounts, mount)
+	}
+
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPath handles the translation of \040 and \011 as per fstab(5).
+func unescapeMountPath(path string) string {
+	path = strings.Replace(path, "\\040", " ", -1)
+	return strings.Replace(path, "\\011", "\t", -1)
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro
+45 22 253:0 /srv /mnt/data\040dir rw,noatime - xfs /dev/mapper/vg-data rw,attr2
+50 22 0:44 / /run rw,nosuid,nodev - tmpfs tmpfs rw,mode=755
+`)
+	got, err := parser.ParseMountInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Block devices resolve through dev/block below the parser's sysfs mount point.
+	want := []MountInfo{
+		{MountID: "22", ParentID: "1", MajorMinor: "8:1", Root: "/", MountPoint: "/", Options: "rw,relatime",
+			Propagation: "shared:1", FsType: "ext4", Source: "/dev/sda1", BlockDevice: "sda1"},
+		{MountID: "45", ParentID: "22", MajorMinor: "253:0", Root: "/srv", MountPoint: "/mnt/data dir", Options: "rw,noatime",
+			FsType: "xfs", Source: "/dev/mapper/vg-data", BlockDevice: "dm-0"},
+		{MountID: "50", ParentID: "22", MajorMinor: "0:44", Root: "/", MountPoint: "/run", Options: "rw,nosuid,nodev",
+			FsType: "tmpfs", Source: "tmpfs"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestParseMountInfoInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	if _, err := parser.ParseMountInfo([]byte("22 1 8:1 / / rw,relatime shared:1 ext4 /dev/sda1 rw\n")); err == nil {
+		t.Error("expected an error for a line without separator")
+	}
+}
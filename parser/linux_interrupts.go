@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// interruptSummaries are the lines of /proc/interrupts that carry a single
+// system wide count instead of one per CPU.
+var interruptSummaries = map[string]bool{
+	"ERR": true,
+	"MIS": true,
+	"Err": true,
+}
+
+func (parser *LinuxParser) ParseSoftIRQs(bytesData []byte) (SoftIRQs, error) {
+	scanner := bufio.NewScanner(bytes.NewBuffer(bytesData))
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("softirqs empty")
+	}
+	cpus := parseCPUHeader(scanner.Text())
+
+	softIRQs := SoftIRQs{}
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 2 {
+			continue
+		}
+
+		vector := strings.TrimSuffix(parts[0], ":")
+		values, err := parseCPUValues(cpus, parts[1:])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse softirq %s: %w", vector, err)
+		}
+		softIRQs[vector] = values
+	}
+
+	return softIRQs, scanner.Err()
+}
+
+func (parser *LinuxParser) ParseInterrupts(bytesData []byte) (Interrupts, error) {
+	scanner := bufio.NewScanner(bytes.NewBuffer(bytesData))
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("interrupts empty")
+	}
+	cpus := parseCPUHeader(scanner.Text())
+
+	interrupts := Interrupts{}
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 2 {
+			continue
+		}
+
+		irq := strings.TrimSuffix(parts[0], ":")
+		if interruptSummaries[irq] {
+			total, err := strconv.ParseUint(parts[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse interrupt %s: %w", irq, err)
+			}
+			interrupts[irq] = Interrupt{Total: total, Summary: true}
+			continue
+		}
+
+		count := len(cpus)
+		if len(parts)-1 < count {
+			return nil, fmt.Errorf("couldn't parse interrupt %s: expected %d values, got %d", irq, count, len(parts)-1)
+		}
+		values, err := parseCPUValues(cpus, parts[1:count+1])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse interrupt %s: %w", irq, err)
+		}
+
+		interrupt := Interrupt{Values: values}
+		rest := parts[count+1:]
+		if _, err := strconv.Atoi(irq); err == nil && len(rest) > 0 {
+			// Numbered IRQs are followed by the controller and the devices.
+			interrupt.Type = rest[0]
+			interrupt.Devices = strings.Join(rest[1:], " ")
+		} else {
+			interrupt.Devices = strings.Join(rest, " ")
+		}
+		interrupts[irq] = interrupt
+	}
+
+	return interrupts, scanner.Err()
+}
+
+// parseCPUHeader returns the CPU numbers of a "CPU0 CPU1 ..." header line.
+// Offline CPUs are left out by the kernel, so the numbers can have gaps.
+func parseCPUHeader(line string) []string {
+	fields := strings.Fields(line)
+	cpus := make([]string, 0, len(fields))
+	for _, field := range fields {
+		cpus = append(cpus, strings.TrimPrefix(field, "CPU"))
+	}
+	return cpus
+}
+
+func parseCPUValues(cpus []string, parts []string) (map[string]uint64, error) {
+	if len(parts) < len(cpus) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(cpus), len(parts))
+	}
+
+	values := make(map[string]uint64, len(cpus))
+	for i, cpu := range cpus {
+		value, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[cpu] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseInterrupts(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    Interrupts
+	}{
+		{
+			fixture: "x86-2cpu",
+			want: Interrupts{
+				"0":   {Type: "IO-APIC", Devices: "2-edge timer", Values: map[string]uint64{"0": 36, "1": 0}},
+				"1":   {Type: "IO-APIC", Devices: "1-edge i8042", Values: map[string]uint64{"0": 9, "1": 7}},
+				"24":  {Type: "PCI-MSI", Devices: "65536-edge nvme0q0, eth0", Values: map[string]uint64{"0": 0, "1": 1042}},
+				"NMI": {Devices: "Non-maskable interrupts", Values: map[string]uint64{"0": 3, "1": 4}},
+				"LOC": {Devices: "Local timer interrupts", Values: map[string]uint64{"0": 512340, "1": 498211}},
+				"ERR": {Total: 2, Summary: true},
+				"MIS": {Total: 0, Summary: true},
+			},
+		},
+		{
+			// With a single CPU the summary lines have as many values as
+			// the header, they must still not be reported for CPU 0.
+			fixture: "x86-1cpu",
+			want: Interrupts{
+				"26":  {Type: "IO-APIC", Devices: "4-edge ttyS0", Values: map[string]uint64{"0": 2}},
+				"LOC": {Devices: "Local timer interrupts", Values: map[string]uint64{"0": 51234}},
+				"ERR": {Total: 5, Summary: true},
+				"MIS": {Total: 1, Summary: true},
+			},
+		},
+	}
+
+	parser := &LinuxParser{}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "interrupts", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parser.ParseInterrupts(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseInterruptsInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	for _, data := range []string{
+		"  CPU0 CPU1\n  0:  1\n",
+		"  CPU0\n  0:  x  IO-APIC\n",
+		"  CPU0\nERR:  x\n",
+	} {
+		if _, err := parser.ParseInterrupts([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestParseSoftIRQs(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "softirqs", "offline-cpu"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := &LinuxParser{}
+	got, err := parser.ParseSoftIRQs(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// CPU1 is offline and missing from the header.
+	want := SoftIRQs{
+		"HI":     {"0": 0, "2": 1},
+		"TIMER":  {"0": 35198, "2": 20101},
+		"NET_RX": {"0": 4066, "2": 512},
+		"RCU":    {"0": 45748, "2": 30011},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	if _, err := parser.ParseSoftIRQs([]byte("  CPU0 CPU1\n  HI:  1\n")); err == nil {
+		t.Error("expected an error for a missing CPU value")
+	}
+}
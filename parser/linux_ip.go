@@ -5,21 +5,36 @@ import (
 	"net"
 )
 
-func (parser *LinuxParser) ParseIPStat() (IPStat, error) {
-	return GetIPs()
+func (parser *LinuxParser) ParseIPStat(filter NetDevFilter) (IPStat, error) {
+	return getIPs(filter)
 }
 
 func GetIPs() ([]string, error) {
-	addrs, err := net.InterfaceAddrs()
+	return getIPs(NetDevFilter{})
+}
+
+func getIPs(filter NetDevFilter) ([]string, error) {
+	ifaces, err := net.Interfaces()
 	if err != nil {
 		return nil, err
 	}
 
 	ips := make([]string, 0)
-	for _, value := range addrs {
-		if ipnet, ok := value.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() != nil {
-				ips = append(ips, ipnet.IP.String())
+	for _, iface := range ifaces {
+		if filter.ignored(iface.Name) {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range addrs {
+			if ipnet, ok := value.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+				if ipnet.IP.To4() != nil {
+					ips = append(ips, ipnet.IP.String())
+				}
 			}
 		}
 	}
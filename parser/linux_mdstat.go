@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdStatusRE   = regexp.MustCompile(`\[(\d+)/(\d+)\]`)
+	mdProgressRE = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+	// Sync operations as named in mdstat, mapped to the reported state.
+	mdSyncStates = []struct{ keyword, state string }{
+		{"recovery", "recovering"},
+		{"resync", "resync"},
+		{"reshape", "reshape"},
+		{"check", "check"},
+		{"repair", "check"},
+	}
+)
+
+func (parser *LinuxParser) ParseMDStat(bytesData []byte) ([]MDStat, error) {
+	var (
+		stats   = make([]MDStat, 0)
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+		// Lines of the array being parsed, the header first.
+		lines []string
+	)
+
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		stat, err := parseMDArray(lines)
+		if err != nil {
+			return err
+		}
+		stats = append(stats, stat)
+		lines = nil
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "Personalities"), strings.HasPrefix(line, "unused devices"):
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			if len(lines) > 0 {
+				lines = append(lines, strings.TrimSpace(line))
+			}
+		default:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// parseMDArray parses the lines of a single array, e.g.
+//
+//	md6 : active raid1 sdb2[2](F) sdc[1](S) sda2[0]
+//	      195310144 blocks [2/1] [U_]
+//	      [=>...................]  recovery =  8.5% (16775552/195310144) finish=17.0min speed=259783K/sec
+func parseMDArray(lines []string) (MDStat, error) {
+	header := strings.Fields(lines[0])
+	if len(header) < 3 || header[1] != ":" {
+		return MDStat{}, fmt.Errorf("invalid array line in mdstat: %s", lines[0])
+	}
+
+	stat := MDStat{
+		Name:    header[0],
+		State:   header[2],
+		Devices: make([]string, 0),
+	}
+
+	// After the state come optional modes like "(auto-read-only)", the
+	// personality (missing for inactive arrays and containers) and the members.
+	members := header[3:]
+	for len(members) > 0 && strings.HasPrefix(members[0], "(") {
+		members = members[1:]
+	}
+	if len(members) > 0 && !strings.Contains(members[0], "[") {
+		stat.Level = members[0]
+		members = members[1:]
+	}
+	for _, member := range members {
+		index := strings.Index(member, "[")
+		if index < 0 {
+			return stat, fmt.Errorf("invalid member %s of %s in mdstat", member, stat.Name)
+		}
+		stat.Devices = append(stat.Devices, member[:index])
+		switch {
+		case strings.Contains(member, "(F)"):
+			stat.DisksFailed++
+		case strings.Contains(member, "(S)"):
+			stat.DisksSpare++
+		}
+	}
+
+	if len(lines) < 2 {
+		// An inactive array without members has no status line and no blocks.
+		if stat.State == "inactive" {
+			stat.SyncCompletion = 1
+			return stat, nil
+		}
+		return stat, fmt.Errorf("missing status line for %s in mdstat", stat.Name)
+	}
+
+	status := strings.Fields(lines[1])
+	if len(status) < 2 || status[1] != "blocks" {
+		return stat, fmt.Errorf("invalid status line for %s in mdstat: %s", stat.Name, lines[1])
+	}
+	blocks, err := strconv.ParseFloat(status[0], 64)
+	if err != nil {
+		return stat, fmt.Errorf("invalid block count for %s in mdstat: %w", stat.Name, err)
+	}
+	stat.BlocksTotal = blocks
+	stat.BlocksSynced = blocks
+	stat.SyncCompletion = 1
+
+	// Redundant levels report "[total/active]", raid0, linear and containers
+	// do not, all their working members are in use. Nothing is active in an
+	// inactive array, whatever the status line says.
+	if match := mdStatusRE.FindStringSubmatch(lines[1]); match != nil {
+		stat.DisksTotal, _ = strconv.ParseFloat(match[1], 64)
+		stat.DisksActive, _ = strconv.ParseFloat(match[2], 64)
+	} else if stat.State != "inactive" {
+		stat.DisksActive = float64(len(stat.Devices)) - stat.DisksFailed - stat.DisksSpare
+		stat.DisksTotal = stat.DisksActive
+	}
+	if stat.State == "inactive" {
+		stat.DisksActive = 0
+	}
+
+	for _, line := range lines[2:] {
+		state := mdSyncState(line)
+		if state == "" {
+			continue
+		}
+		stat.State = state
+
+		// resync=DELAYED and resync=PENDING are queued, nothing is synced yet.
+		if strings.Contains(line, "=DELAYED") || strings.Contains(line, "=PENDING") {
+			stat.BlocksSynced = 0
+			stat.SyncCompletion = 0
+			break
+		}
+
+		match := mdProgressRE.FindStringSubmatch(line)
+		if match == nil {
+			return stat, fmt.Errorf("invalid sync line for %s in mdstat: %s", stat.Name, line)
+		}
+		synced, _ := strconv.ParseFloat(match[1], 64)
+		total, _ := strconv.ParseFloat(match[2], 64)
+		stat.BlocksSynced = synced
+		if total > 0 {
+			stat.SyncCompletion = synced / total
+		}
+		break
+	}
+
+	return stat, nil
+}
+
+func mdSyncState(line string) string {
+	for _, sync := range mdSyncStates {
+		if (strings.HasPrefix(line, "[") && strings.Contains(line, sync.keyword+" =")) ||
+			strings.HasPrefix(line, sync.keyword+"=") {
+			return sync.state
+		}
+	}
+	return ""
+}
+
+func (parser *LinuxParser) ParseMDArray(name string) (MDArray, error) {
+	dir := parser.sysFSPath("block", name, "md")
+	array := MDArray{
+		ArrayState: readSysFileFirst(filepath.Join(dir, "array_state")),
+		SyncAction: readSysFileFirst(filepath.Join(dir, "sync_action")),
+	}
+
+	values := []struct {
+		file  string
+		value **float64
+	}{
+		{"degraded", &array.Degraded},
+		{"mismatch_cnt", &array.MismatchCount},
+	}
+	for _, v := range values {
+		content := readSysFileFirst(filepath.Join(dir, v.file))
+		if content == "" {
+			continue
+		}
+		fv, err := strconv.ParseFloat(content, 64)
+		if err != nil {
+			return array, fmt.Errorf("invalid value %s in %s of %s: %w", content, v.file, name, err)
+		}
+		*v.value = &fv
+	}
+
+	return array, nil
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMDStat(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []MDStat
+	}{
+		{
+			fixture: "redundant",
+			want: []MDStat{
+				{Name: "md3", State: "active", Level: "raid6", Devices: []string{"sda1", "sdh1", "sdg1", "sdf1", "sde1", "sdd1", "sdc1", "sdb1"},
+					DisksActive: 8, DisksTotal: 8, BlocksTotal: 5853468288, BlocksSynced: 5853468288, SyncCompletion: 1},
+				{Name: "md0", State: "active", Level: "raid1", Devices: []string{"sdk", "sdi1", "sdj1"},
+					DisksActive: 2, DisksSpare: 1, DisksTotal: 2, BlocksTotal: 248896, BlocksSynced: 248896, SyncCompletion: 1},
+				{Name: "md6", State: "recovering", Level: "raid1", Devices: []string{"sdb2", "sdc", "sda2"},
+					DisksActive: 1, DisksFailed: 1, DisksSpare: 1, DisksTotal: 2, BlocksTotal: 195310144, BlocksSynced: 16775552, SyncCompletion: 16775552.0 / 195310144},
+				{Name: "md7", State: "active", Level: "raid6", Devices: []string{"sdb1", "sde1", "sdd1", "sdc1"},
+					DisksActive: 3, DisksFailed: 1, DisksTotal: 4, BlocksTotal: 7813735424, BlocksSynced: 7813735424, SyncCompletion: 1},
+				{Name: "md8", State: "resync", Level: "raid1", Devices: []string{"sdb1", "sda1", "sdc", "sde"},
+					DisksActive: 2, DisksSpare: 2, DisksTotal: 2, BlocksTotal: 195310144, BlocksSynced: 16775552, SyncCompletion: 16775552.0 / 195310144},
+				{Name: "md9", State: "resync", Level: "raid1", Devices: []string{"sdc2", "sdd2", "sdb2", "sda2", "sde", "sdf", "sdg"},
+					DisksActive: 4, DisksFailed: 2, DisksSpare: 1, DisksTotal: 4, BlocksTotal: 523968},
+				{Name: "md10", State: "check", Level: "raid10", Devices: []string{"sdd", "sdc", "sdb", "sda"},
+					DisksActive: 4, DisksTotal: 4, BlocksTotal: 1953260544, BlocksSynced: 486539008, SyncCompletion: 486539008.0 / 1953260544},
+				{Name: "md11", State: "resync", Level: "raid1", Devices: []string{"sdb2", "sdc2", "sdc3", "hda", "ssdc2"},
+					DisksActive: 2, DisksFailed: 1, DisksSpare: 2, DisksTotal: 2, BlocksTotal: 4190208},
+			},
+		},
+		{
+			fixture: "striped",
+			want: []MDStat{
+				{Name: "md12", State: "active", Level: "raid0", Devices: []string{"sdc2", "sdd2"},
+					DisksActive: 2, DisksTotal: 2, BlocksTotal: 3886394368, BlocksSynced: 3886394368, SyncCompletion: 1},
+				{Name: "md120", State: "active", Level: "linear", Devices: []string{"sda1", "sdb1"},
+					DisksActive: 2, DisksTotal: 2, BlocksTotal: 2095104, BlocksSynced: 2095104, SyncCompletion: 1},
+				{Name: "md101", State: "active", Level: "raid0", Devices: []string{"sdb", "sdd", "sdc"},
+					DisksActive: 3, DisksTotal: 3, BlocksTotal: 322560, BlocksSynced: 322560, SyncCompletion: 1},
+			},
+		},
+		{
+			fixture: "containers",
+			want: []MDStat{
+				{Name: "md126", State: "resync", Level: "raid1", Devices: []string{"sdb", "sdc"},
+					DisksActive: 2, DisksTotal: 2, BlocksTotal: 1855870976, BlocksSynced: 23456, SyncCompletion: 23456.0 / 1855870976},
+				{Name: "md127", State: "inactive", Devices: []string{"sdb", "sdc"},
+					DisksSpare: 2, BlocksTotal: 6306, BlocksSynced: 6306, SyncCompletion: 1},
+				{Name: "md125", State: "inactive", Devices: []string{}, SyncCompletion: 1},
+				{Name: "md4", State: "inactive", Level: "raid1", Devices: []string{"sda3", "sdb3"},
+					DisksFailed: 1, DisksSpare: 1, DisksTotal: 2, BlocksTotal: 4883648, BlocksSynced: 4883648, SyncCompletion: 1},
+			},
+		},
+	}
+
+	parser := &LinuxParser{}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "mdstat", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parser.ParseMDStat(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("want %d arrays, got %d", len(test.want), len(got))
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], test.want[i]) {
+					t.Errorf("want %+v, got %+v", test.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseMDStatInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	if _, err := parser.ParseMDStat([]byte("md0 : active raid1 sda1[0] sdb1[1]\n")); err == nil {
+		t.Error("expected an error for a missing status line")
+	}
+}
+
+func TestParseMDArray(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	array, err := parser.ParseMDArray("md0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if array.ArrayState != "clean" || array.SyncAction != "check" ||
+		array.Degraded == nil || *array.Degraded != 1 ||
+		array.MismatchCount == nil || *array.MismatchCount != 128 {
+		t.Errorf("unexpected md0 attributes %+v", array)
+	}
+
+	// Arrays that are not assembled have no md directory.
+	array, err = parser.ParseMDArray("md1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(array, MDArray{}) {
+		t.Errorf("want no attributes for md1, got %+v", array)
+	}
+}
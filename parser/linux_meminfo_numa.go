@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseNUMAMemoryStat() ([]NUMAMemoryStat, error) {
+	nodes, err := filepath.Glob(parser.sysFSPath("devices", "system", "node", "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]NUMAMemoryStat, 0, len(nodes))
+	for _, node := range nodes {
+		stat := NUMAMemoryStat{Node: strings.TrimPrefix(filepath.Base(node), "node")}
+
+		bytesData, err := ioutil.ReadFile(filepath.Join(node, "meminfo"))
+		if err != nil {
+			return nil, err
+		}
+		if stat.MemInfo, err = parseNUMAMemInfo(bytesData); err != nil {
+			return nil, fmt.Errorf("node %s: %w", stat.Node, err)
+		}
+
+		bytesData, err = ioutil.ReadFile(filepath.Join(node, "numastat"))
+		if err != nil {
+			return nil, err
+		}
+		if stat.NUMAStat, err = parseNUMAStat(bytesData); err != nil {
+			return nil, fmt.Errorf("node %s: %w", stat.Node, err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+func parseNUMAMemInfo(bytesData []byte) (map[string]float64, error) {
+	var (
+		memInfo = map[string]float64{}
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Lines look like "Node 0 MemTotal:  4554488 kB".
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) < 4 {
+			return nil, fmt.Errorf("invalid line in meminfo: %s", line)
+		}
+		fv, err := strconv.ParseFloat(parts[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in meminfo: %w", err)
+		}
+		key := strings.TrimSuffix(parts[2], ":")
+		key = reParens.ReplaceAllString(key, "_${1}")
+		switch len(parts) {
+		case 4: // no unit
+		case 5: // has unit, we presume kB
+			fv *= 1024
+			key = key + "_bytes"
+		default:
+			return nil, fmt.Errorf("invalid line in meminfo: %s", line)
+		}
+		memInfo[key] = fv
+	}
+
+	return memInfo, scanner.Err()
+}
+
+func parseNUMAStat(bytesData []byte) (map[string]float64, error) {
+	var (
+		numaStat = map[string]float64{}
+		scanner  = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid line in numastat: %s", scanner.Text())
+		}
+		fv, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in numastat: %w", err)
+		}
+		numaStat[parts[0]] = fv
+	}
+
+	return numaStat, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseNUMAMemoryStat(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.ParseNUMAMemoryStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []NUMAMemoryStat{
+		{
+			Node: "0",
+			MemInfo: map[string]float64{
+				"MemTotal_bytes":    4816632 * 1024,
+				"MemFree_bytes":     3338924 * 1024,
+				"Active_anon_bytes": 12 * 1024,
+				"HugePages_Total":   0,
+			},
+			NUMAStat: map[string]float64{"numa_hit": 10832087, "numa_miss": 0},
+		},
+		{
+			Node: "1",
+			MemInfo: map[string]float64{
+				"MemTotal_bytes":    4194304 * 1024,
+				"MemFree_bytes":     1048576 * 1024,
+				"Active_anon_bytes": 512 * 1024,
+				"HugePages_Total":   8,
+			},
+			NUMAStat: map[string]float64{"numa_hit": 42, "numa_miss": 7},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
@@ -46,3 +46,131 @@ func (parser *LinuxParser) ParseMemoryStat(bytesData []byte) (MemoryStat, error)
 
 	return memInfo, scanner.Err()
 }
+
+func (parser *LinuxParser) ParseBuddyInfo(bytesData []byte) ([]BuddyInfo, error) {
+	var (
+		buddyInfo = make([]BuddyInfo, 0)
+		scanner   = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Lines look like "Node 0, zone   Normal   2875   2284 ...".
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) < 5 || parts[0] != "Node" || parts[2] != "zone" {
+			return nil, fmt.Errorf("invalid line in buddyinfo: %s", line)
+		}
+
+		info := BuddyInfo{
+			Node:   strings.TrimSuffix(parts[1], ","),
+			Zone:   parts[3],
+			Blocks: make([]float64, 0, len(parts)-4),
+		}
+		for _, part := range parts[4:] {
+			fv, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in buddyinfo: %w", err)
+			}
+			info.Blocks = append(info.Blocks, fv)
+		}
+		buddyInfo = append(buddyInfo, info)
+	}
+
+	return buddyInfo, scanner.Err()
+}
+
+var (
+	// zoneInfoPageFields are the per-zone page counts reported by ParseZoneInfo.
+	// The remaining counters of a zone duplicate /proc/vmstat.
+	zoneInfoPageFields = map[string]bool{
+		"free": true, "boost": true, "min": true, "low": true, "high": true, "promo": true,
+		"spanned": true, "present": true, "managed": true, "cma": true,
+	}
+)
+
+func (parser *LinuxParser) ParseZoneInfo(bytesData []byte) ([]ZoneInfo, error) {
+	var (
+		zoneInfo = make([]*ZoneInfo, 0)
+		scanner  = bufio.NewScanner(bytes.NewBuffer(bytesData))
+		// Protection values are indexed by the zones of the node, in order.
+		protections = map[*ZoneInfo][]float64{}
+		zone        *ZoneInfo
+		pagesets    bool
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+
+		switch {
+		case parts[0] == "Node":
+			if len(parts) < 4 {
+				return nil, fmt.Errorf("invalid zone header in zoneinfo: %s", line)
+			}
+			zone = &ZoneInfo{
+				Node:       strings.TrimSuffix(parts[1], ","),
+				Zone:       parts[3],
+				Pages:      map[string]float64{},
+				Protection: map[string]float64{},
+			}
+			zoneInfo = append(zoneInfo, zone)
+			pagesets = false
+		case zone == nil || pagesets:
+			continue
+		case parts[0] == "pagesets":
+			// Per-CPU pageset fields such as "high" would clash with the watermarks.
+			pagesets = true
+		case parts[0] == "protection:":
+			values := strings.Trim(strings.Join(parts[1:], ""), "()")
+			for _, value := range strings.Split(values, ",") {
+				fv, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid protection in zoneinfo: %w", err)
+				}
+				protections[zone] = append(protections[zone], fv)
+			}
+		default:
+			// "pages free 3840" carries the first field behind a prefix.
+			if parts[0] == "pages" {
+				parts = parts[1:]
+			}
+			if len(parts) != 2 || !zoneInfoPageFields[parts[0]] {
+				continue
+			}
+			fv, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s in zoneinfo: %w", parts[1], err)
+			}
+			zone.Pages[parts[0]] = fv
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Resolve the protection indexes once every zone of a node is known.
+	nodeZones := map[string][]string{}
+	for _, info := range zoneInfo {
+		nodeZones[info.Node] = append(nodeZones[info.Node], info.Zone)
+	}
+	stats := make([]ZoneInfo, 0, len(zoneInfo))
+	for _, info := range zoneInfo {
+		zones := nodeZones[info.Node]
+		for i, value := range protections[info] {
+			name := strconv.Itoa(i)
+			if i < len(zones) {
+				name = zones[i]
+			}
+			info.Protection[name] = value
+		}
+		stats = append(stats, *info)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseBuddyInfo(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "buddyinfo", "two-nodes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := &LinuxParser{}
+	got, err := parser.ParseBuddyInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []BuddyInfo{
+		{Node: "0", Zone: "DMA", Blocks: []float64{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 3}},
+		{Node: "0", Zone: "DMA32", Blocks: []float64{1734, 810, 276, 284, 351, 59, 6, 2, 4, 2, 701}},
+		{Node: "0", Zone: "Normal", Blocks: []float64{1165, 1292, 1444, 966, 282, 99, 27, 8, 1, 0, 0}},
+		{Node: "1", Zone: "Normal", Blocks: []float64{412, 301, 198, 87, 40, 12, 3, 1, 0, 0, 96}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestParseBuddyInfoInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	for _, line := range []string{
+		"Node 0, zone DMA",
+		"Node 0, area DMA 1 2 3",
+		"Node 0, zone DMA 1 x 3",
+	} {
+		if _, err := parser.ParseBuddyInfo([]byte(line + "\n")); err == nil {
+			t.Errorf("expected an error for %q", line)
+		}
+	}
+}
+
+func TestParseZoneInfo(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "zoneinfo", "two-nodes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := &LinuxParser{}
+	got, err := parser.ParseZoneInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The per-node stats, the zone counters and the per-CPU pagesets are
+	// skipped, and the unpopulated zones of node 1 still take their slot in
+	// the protection arrays.
+	want := []ZoneInfo{
+		{
+			Node:       "0",
+			Zone:       "DMA",
+			Pages:      zoneInfoPages(3840, 0, 42, 52, 62, 72, 4095, 3998, 3840),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 3024, "Normal": 5998, "Movable": 5998},
+		},
+		{
+			Node:       "0",
+			Zone:       "DMA32",
+			Pages:      zoneInfoPages(734746, 0, 8498, 10622, 12746, 14870, 1044480, 782336, 774334),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 2974, "Movable": 2974},
+		},
+		{
+			Node:       "0",
+			Zone:       "Normal",
+			Pages:      zoneInfoPages(27941, 5120, 13475, 15563, 17651, 19739, 786432, 786432, 761364),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 0, "Movable": 0},
+		},
+		{
+			Node:       "0",
+			Zone:       "Movable",
+			Pages:      zoneInfoPages(0, 0, 32, 32, 32, 32, 0, 0, 0),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 0, "Movable": 0},
+		},
+		{
+			Node:       "1",
+			Zone:       "DMA",
+			Pages:      zoneInfoPages(0, 0, 0, 0, 0, 0, 0, 0, 0),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 4023, "Movable": 4023},
+		},
+		{
+			Node:       "1",
+			Zone:       "DMA32",
+			Pages:      zoneInfoPages(0, 0, 0, 0, 0, 0, 0, 0, 0),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 4023, "Movable": 4023},
+		},
+		{
+			Node:       "1",
+			Zone:       "Normal",
+			Pages:      zoneInfoPages(402113, 0, 16384, 20480, 24576, 28672, 1048576, 1048576, 1030104),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 0, "Movable": 0},
+		},
+		{
+			Node:       "1",
+			Zone:       "Movable",
+			Pages:      zoneInfoPages(0, 0, 0, 0, 0, 0, 0, 0, 0),
+			Protection: map[string]float64{"DMA": 0, "DMA32": 0, "Normal": 0, "Movable": 0},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d zones, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("want %+v, got %+v", want[i], got[i])
+		}
+	}
+}
+
+func TestParseZoneInfoInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	for _, data := range []string{
+		"Node 0,\n",
+		"Node 0, zone DMA\n  pages free x\n",
+		"Node 0, zone DMA\n  protection: (0, x)\n",
+	} {
+		if _, err := parser.ParseZoneInfo([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func zoneInfoPages(free, boost, min, low, high, promo, spanned, present, managed float64) map[string]float64 {
+	return map[string]float64{
+		"free": free, "boost": boost, "min": min, "low": low, "high": high, "promo": promo,
+		"spanned": spanned, "present": present, "managed": managed, "cma": 0,
+	}
+}
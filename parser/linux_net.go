@@ -13,27 +13,13 @@ import (
 var (
 	procNetDevInterfaceRE = regexp.MustCompile(`^(.+): *(.+)$`)
 	procNetDevFieldSep    = regexp.MustCompile(` +`)
-	// newNetDevFilter       = newNetDevFilter("", "")
 )
 
-// type netDevFilter struct {
-// 	ignorePattern *regexp.Regexp
-// 	acceptPattern *regexp.Regexp
-// }
-
-// func newNetDevFilter(ignoredPattern, acceptPattern string) (f netDevFilter) {
-// 	if ignoredPattern != "" {
-// 		f.ignorePattern = regexp.MustCompile(ignoredPattern)
-// 	}
-
-// 	if acceptPattern != "" {
-// 		f.acceptPattern = regexp.MustCompile(acceptPattern)
-// 	}
-
-// 	return
-// }
+func (f NetDevFilter) ignored(device string) bool {
+	return ignoredByPatterns(device, f.Include, f.Exclude)
+}
 
-func (parser *LinuxParser) ParseNetStat(bytesData []byte) (NetStat, error) {
+func (parser *LinuxParser) ParseNetStat(bytesData []byte, filter NetDevFilter) (NetStat, error) {
 	scanner := bufio.NewScanner(bytes.NewBuffer(bytesData))
 	scanner.Scan() // skip first header
 	scanner.Scan()
@@ -56,10 +42,9 @@ func (parser *LinuxParser) ParseNetStat(bytesData []byte) (NetStat, error) {
 		}
 
 		dev := parts[1]
-		// if filter.ignored(dev) {
-		// 	level.Debug(logger).Log("msg", "Ignoring device", "device", dev)
-		// 	continue
-		// }
+		if filter.ignored(dev) {
+			continue
+		}
 
 		values := procNetDevFieldSep.Split(strings.TrimLeft(parts[2], " "), -1)
 		if len(values) != headerLength {
@@ -1,9 +1,126 @@
 package parser
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"github.com/prometheus/procfs/sysfs"
+	"golang.org/x/sys/unix"
 )
 
-func (parser *LinuxParser) ParseNetClass() (sysfs.NetClass, error) {
-	return parser.fs.NetClass()
+// ParseNetClass reads /sys/class/net/<iface> of the devices accepted by the
+// filter. Ignored devices are skipped before any of their files are read.
+func (parser *LinuxParser) ParseNetClass(filter NetDevFilter) (NetClass, error) {
+	devices, err := parser.fs.NetClassDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	netClass := NetClass{}
+	for _, device := range devices {
+		if filter.ignored(device) {
+			continue
+		}
+
+		devicePath := parser.sysFSPath("class", "net", device)
+		iface, err := parseNetClassIface(devicePath)
+		// Virtual devices such as veths may be deleted while being read.
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		iface.Name = device
+
+		// device/driver links to /sys/bus/<bus>/drivers/<driver>.
+		driver, err := os.Readlink(filepath.Join(devicePath, "device", "driver"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if driver != "" {
+			driver = filepath.Base(driver)
+		}
+
+		netClass[device] = NetClassIface{NetClassIface: iface, Driver: driver}
+	}
+
+	return netClass, nil
+}
+
+// parseNetClassIface follows sysfs.NetClass, which offers no way to read a
+// single device.
+func parseNetClassIface(devicePath string) (sysfs.NetClassIface, error) {
+	var (
+		iface     = sysfs.NetClassIface{}
+		intFields = map[string]**int64{
+			"addr_assign_type":   &iface.AddrAssignType,
+			"addr_len":           &iface.AddrLen,
+			"carrier":            &iface.Carrier,
+			"carrier_changes":    &iface.CarrierChanges,
+			"carrier_up_count":   &iface.CarrierUpCount,
+			"carrier_down_count": &iface.CarrierDownCount,
+			"dev_id":             &iface.DevID,
+			"dormant":            &iface.Dormant,
+			"flags":              &iface.Flags,
+			"ifindex":            &iface.IfIndex,
+			"iflink":             &iface.IfLink,
+			"link_mode":          &iface.LinkMode,
+			"mtu":                &iface.MTU,
+			"name_assign_type":   &iface.NameAssignType,
+			"netdev_group":       &iface.NetDevGroup,
+			"speed":              &iface.Speed,
+			"tx_queue_len":       &iface.TxQueueLen,
+			"type":               &iface.Type,
+		}
+		stringFields = map[string]*string{
+			"address":        &iface.Address,
+			"broadcast":      &iface.Broadcast,
+			"duplex":         &iface.Duplex,
+			"ifalias":        &iface.IfAlias,
+			"operstate":      &iface.OperState,
+			"phys_port_id":   &iface.PhysPortID,
+			"phys_port_name": &iface.PhysPortName,
+			"phys_switch_id": &iface.PhysSwitchID,
+		}
+	)
+
+	files, err := ioutil.ReadDir(devicePath)
+	if err != nil {
+		return iface, err
+	}
+
+	for _, file := range files {
+		intField, isInt := intFields[file.Name()]
+		stringField, isString := stringFields[file.Name()]
+		if !file.Mode().IsRegular() || !isInt && !isString {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(devicePath, file.Name()))
+		if err != nil {
+			// Attributes such as speed and duplex cannot be read while the
+			// link is down.
+			if os.IsNotExist(err) || os.IsPermission(err) ||
+				errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EINVAL) {
+				continue
+			}
+			return iface, err
+		}
+
+		value := strings.TrimSpace(string(content))
+		if isString {
+			*stringField = value
+			continue
+		}
+		if v, err := strconv.ParseInt(value, 0, 64); err == nil {
+			*intField = &v
+		}
+	}
+
+	return iface, nil
 }
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestParseNetClass(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// veth1a2b3c links to a device that is already gone and is skipped.
+	netClass, err := parser.ParseNetClass(NetDevFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := netClass["veth1a2b3c"]; ok || len(netClass) != 11 {
+		t.Fatalf("want all devices but veth1a2b3c, got %+v", netClass)
+	}
+
+	eth0 := netClass["eth0"]
+	if eth0.Name != "eth0" || eth0.Address != "00:16:3e:4a:2b:01" || eth0.Duplex != "full" ||
+		eth0.OperState != "up" || eth0.IfAlias != "uplink" || eth0.Driver != "e1000e" {
+		t.Errorf("unexpected eth0 attributes %+v", eth0)
+	}
+	if eth0.MTU == nil || *eth0.MTU != 1500 || eth0.Speed == nil || *eth0.Speed != 1000 ||
+		eth0.Flags == nil || *eth0.Flags != 0x1003 {
+		t.Errorf("unexpected eth0 values %+v", eth0)
+	}
+
+	lo := netClass["lo"]
+	if lo.Driver != "" || lo.OperState != "unknown" || lo.Speed != nil || lo.MTU == nil || *lo.MTU != 65536 {
+		t.Errorf("unexpected lo attributes %+v", lo)
+	}
+}
+
+func TestParseNetClassFilter(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netClass, err := parser.ParseNetClass(NetDevFilter{Include: regexp.MustCompile(`^(eth0|lo)$`), Exclude: regexp.MustCompile(`^lo$`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := netClass["eth0"]; len(netClass) != 1 || !ok {
+		t.Errorf("want only eth0, got %+v", netClass)
+	}
+}
@@ -0,0 +1,209 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// netLinkStatKeys names the fields of struct rtnl_link_stats64 in order.
+// Fields /proc/net/dev reports keep the key ParseNetStat uses for them.
+// Older kernels send fewer fields, the missing ones are not reported.
+var netLinkStatKeys = []string{
+	"receive_packets",
+	"transmit_packets",
+	"receive_bytes",
+	"transmit_bytes",
+	"receive_errs",
+	"transmit_errs",
+	"receive_drop",
+	"transmit_drop",
+	"receive_multicast",
+	"transmit_colls",
+	"receive_length_errors",
+	"receive_over_errors",
+	"receive_crc_errors",
+	"receive_frame_errors",
+	"receive_fifo",
+	"receive_missed_errors",
+	"transmit_aborted_errors",
+	"transmit_carrier_errors",
+	"transmit_fifo",
+	"transmit_heartbeat_errors",
+	"transmit_window_errors",
+	"receive_compressed",
+	"transmit_compressed",
+	"receive_nohandler",
+	"receive_otherhost_dropped",
+}
+
+// netLinkProcSums are the /proc/net/dev fields the kernel sums from several
+// rtnl_link_stats64 fields. They are set under the ParseNetStat key so both
+// sources report the same series, receive_drop replaces the raw rx_dropped.
+var netLinkProcSums = map[string][]string{
+	"receive_drop":     {"receive_drop", "receive_missed_errors"},
+	"receive_frame":    {"receive_length_errors", "receive_over_errors", "receive_crc_errors", "receive_frame_errors"},
+	"transmit_carrier": {"transmit_carrier_errors", "transmit_aborted_errors", "transmit_window_errors", "transmit_heartbeat_errors"},
+}
+
+// netLinkOperStates are the RFC 2863 states of IFLA_OPERSTATE, matching
+// the strings of /sys/class/net/<iface>/operstate.
+var netLinkOperStates = []string{"unknown", "notpresent", "down", "lowerlayerdown", "testing", "dormant", "up"}
+
+var nativeEndian binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	var probe uint16 = 1
+	if *(*byte)(unsafe.Pointer(&probe)) == 0 {
+		nativeEndian = binary.BigEndian
+	}
+}
+
+// ParseNetLinks dumps all network devices with a single RTM_GETLINK request.
+func (parser *LinuxParser) ParseNetLinks(filter NetDevFilter) ([]NetLink, error) {
+	data, err := syscall.NetlinkRIB(unix.RTM_GETLINK, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("netlink dump: %w", err)
+	}
+	return parseNetLinkMessages(data, filter)
+}
+
+func parseNetLinkMessages(data []byte, filter NetDevFilter) ([]NetLink, error) {
+	messages, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		links = make([]NetLink, 0, len(messages))
+		names = make(map[uint32]string, len(messages))
+		// Masters are referred to by index and may come later in the dump.
+		masters = make(map[int]uint32)
+	)
+
+	for _, message := range messages {
+		if message.Header.Type == unix.NLMSG_ERROR && len(message.Data) >= 4 {
+			errno := int32(nativeEndian.Uint32(message.Data))
+			return nil, fmt.Errorf("netlink dump: %w", syscall.Errno(-errno))
+		}
+		if message.Header.Type != unix.RTM_NEWLINK || len(message.Data) < unix.SizeofIfInfomsg {
+			continue
+		}
+
+		attrs, err := syscall.ParseNetlinkRouteAttr(&message)
+		if err != nil {
+			return nil, err
+		}
+
+		index := nativeEndian.Uint32(message.Data[4:8])
+		link := NetLink{Index: index}
+		for _, attr := range attrs {
+			if attr.Attr.Type == unix.IFLA_IFNAME {
+				link.Name = cString(attr.Value)
+			}
+		}
+		names[index] = link.Name
+		if link.Name == "" || filter.ignored(link.Name) {
+			continue
+		}
+
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case unix.IFLA_MTU:
+				if len(attr.Value) >= 4 {
+					link.MTU = nativeEndian.Uint32(attr.Value)
+				}
+			case unix.IFLA_OPERSTATE:
+				if len(attr.Value) >= 1 && int(attr.Value[0]) < len(netLinkOperStates) {
+					link.OperState = netLinkOperStates[attr.Value[0]]
+				}
+			case unix.IFLA_MASTER:
+				if len(attr.Value) >= 4 {
+					masters[len(links)] = nativeEndian.Uint32(attr.Value)
+				}
+			case unix.IFLA_LINKINFO:
+				for _, info := range parseNestedAttrs(attr.Value) {
+					switch info.Attr.Type {
+					case unix.IFLA_INFO_KIND:
+						link.Kind = cString(info.Value)
+					case unix.IFLA_INFO_SLAVE_KIND:
+						link.SlaveKind = cString(info.Value)
+					}
+				}
+			case unix.IFLA_STATS64:
+				link.Stats = make(map[string]uint64, len(netLinkStatKeys))
+				for i, key := range netLinkStatKeys {
+					if len(attr.Value) < (i+1)*8 {
+						break
+					}
+					link.Stats[key] = nativeEndian.Uint64(attr.Value[i*8:])
+				}
+				addNetLinkProcSums(link.Stats)
+			}
+		}
+		links = append(links, link)
+	}
+
+	for i, master := range masters {
+		links[i].Master = names[master]
+	}
+
+	return links, nil
+}
+
+// addNetLinkProcSums sets the netLinkProcSums whose fields were all sent.
+func addNetLinkProcSums(stats map[string]uint64) {
+	sums := make(map[string]uint64, len(netLinkProcSums))
+	for key, fields := range netLinkProcSums {
+		var (
+			sum      uint64
+			complete = true
+		)
+		for _, field := range fields {
+			value, ok := stats[field]
+			complete = complete && ok
+			sum += value
+		}
+		if complete {
+			sums[key] = sum
+		}
+	}
+	for key, sum := range sums {
+		stats[key] = sum
+	}
+}
+
+// parseNestedAttrs splits the payload of a nested attribute such as
+// IFLA_LINKINFO into its attributes.
+func parseNestedAttrs(b []byte) []syscall.NetlinkRouteAttr {
+	var attrs []syscall.NetlinkRouteAttr
+	for len(b) >= unix.SizeofRtAttr {
+		length := int(nativeEndian.Uint16(b[0:2]))
+		if length < unix.SizeofRtAttr || length > len(b) {
+			break
+		}
+		attrs = append(attrs, syscall.NetlinkRouteAttr{
+			Attr:  syscall.RtAttr{Len: uint16(length), Type: nativeEndian.Uint16(b[2:4])},
+			Value: b[unix.SizeofRtAttr:length],
+		})
+
+		aligned := (length + unix.RTA_ALIGNTO - 1) &^ (unix.RTA_ALIGNTO - 1)
+		if aligned > len(b) {
+			break
+		}
+		b = b[aligned:]
+	}
+	return attrs
+}
+
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseNetLinkMessages(t *testing.T) {
+	tests := []struct {
+		fixture string
+		filter  NetDevFilter
+		want    []NetLink
+	}{
+		{
+			// Recorded from a 6.18 kernel, which sends all 25 counters.
+			fixture: "loopback",
+			want: []NetLink{
+				{
+					Index:     1,
+					Name:      "lo",
+					OperState: "unknown",
+					MTU:       65536,
+					Stats: map[string]uint64{
+						"receive_packets": 3414, "transmit_packets": 3414,
+						"receive_bytes": 31661581, "transmit_bytes": 31661581,
+						"receive_errs": 0, "transmit_errs": 0,
+						"receive_drop": 0, "transmit_drop": 0,
+						"receive_multicast": 0, "transmit_colls": 0,
+						"receive_length_errors": 0, "receive_over_errors": 0,
+						"receive_crc_errors": 0, "receive_frame_errors": 0,
+						"receive_fifo": 0, "receive_missed_errors": 0,
+						"transmit_aborted_errors": 0, "transmit_carrier_errors": 0,
+						"transmit_fifo": 0, "transmit_heartbeat_errors": 0,
+						"transmit_window_errors": 0, "receive_compressed": 0,
+						"transmit_compressed": 0, "receive_nohandler": 0,
+						"receive_otherhost_dropped": 0,
+						// Sums of /proc/net/dev.
+						"receive_frame": 0, "transmit_carrier": 0,
+					},
+				},
+			},
+		},
+		{
+			// A bond slave listed before its master, with the 23 counters of
+			// kernels before 4.6.
+			fixture: "bond",
+			filter:  NetDevFilter{Exclude: regexp.MustCompile(`^veth`)},
+			want: []NetLink{
+				{
+					Index:     2,
+					Name:      "eth0",
+					OperState: "up",
+					MTU:       9000,
+					Master:    "bond0",
+					SlaveKind: "bond",
+					Stats: netLinkTestStats(23, 100, map[string]uint64{
+						"receive_drop":     106 + 115,
+						"receive_frame":    110 + 111 + 112 + 113,
+						"transmit_carrier": 117 + 116 + 120 + 119,
+					}),
+				},
+				{
+					Index:     4,
+					Name:      "bond0",
+					OperState: "up",
+					MTU:       9000,
+					Kind:      "bond",
+					Stats: netLinkTestStats(23, 1000, map[string]uint64{
+						"receive_drop":     1006 + 1015,
+						"receive_frame":    1010 + 1011 + 1012 + 1013,
+						"transmit_carrier": 1017 + 1016 + 1020 + 1019,
+					}),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "netlink", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseNetLinkMessages(data, test.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// netLinkTestStats returns the first n counters numbered from base, and the
+// /proc/net/dev sums.
+func netLinkTestStats(n int, base uint64, sums map[string]uint64) map[string]uint64 {
+	stats := make(map[string]uint64, n+len(sums))
+	for i, key := range netLinkStatKeys[:n] {
+		stats[key] = base + uint64(i)
+	}
+	for key, value := range sums {
+		stats[key] = value
+	}
+	return stats
+}
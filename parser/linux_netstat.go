@@ -3,36 +3,37 @@ package parser
 import (
 	"bufio"
 	"bytes"
-	"context"
-	"exporter/util"
 	"fmt"
-	"log"
+	"io/ioutil"
+	"os"
 	"strings"
 )
 
 func (parser *LinuxParser) ParseNetStatInfo() (map[string]map[string]string, error) {
-	bytes, err := util.ExecCommand(context.Background(), "cat", "/proc/net/netstat")
+	bytesData, err := ioutil.ReadFile(procFilePath("net/netstat"))
 	if err != nil {
 		return nil, err
 	}
-	netStats, err := parseNetStats(bytes)
+	netStats, err := parseNetStats(bytesData)
 	if err != nil {
 		return nil, err
 	}
-	bytes, err = util.ExecCommand(context.Background(), "cat", "/proc/net/snmp")
+	bytesData, err = ioutil.ReadFile(procFilePath("net/snmp"))
 	if err != nil {
 		return nil, err
 	}
-	snmpStats, err := parseNetStats(bytes)
+	snmpStats, err := parseNetStats(bytesData)
 	if err != nil {
 		return nil, err
 	}
 
-	bytes, err = util.ExecCommand(context.Background(), "cat", "/proc/net/snmp6")
-	if err != nil {
-		log.Println(err)
-	} else {
-		snmp6Stats, err := parseSNMP6Stats(bytes)
+	// snmp6 is missing when IPv6 is disabled.
+	bytesData, err = ioutil.ReadFile(procFilePath("net/snmp6"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		snmp6Stats, err := parseSNMP6Stats(bytesData)
 		if err != nil {
 			return nil, err
 		}
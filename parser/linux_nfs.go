@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/procfs"
+	"github.com/prometheus/procfs/nfs"
+)
+
+var (
+	// Lines of /proc/net/rpc/nfs and /proc/net/rpc/nfsd known to the procfs
+	// parsers, which reject anything else. Newer kernels add lines such as
+	// wdeleg_getattr that would otherwise fail the whole file.
+	nfsClientLines = map[string]bool{"net": true, "rpc": true, "proc2": true, "proc3": true, "proc4": true}
+	nfsServerLines = map[string]bool{
+		"rc": true, "fh": true, "io": true, "th": true, "ra": true, "net": true, "rpc": true,
+		"proc2": true, "proc3": true, "proc4": true, "proc4ops": true,
+	}
+)
+
+func (parser *LinuxParser) ParseNFSClientStat(bytesData []byte) (*nfs.ClientRPCStats, error) {
+	return nfs.ParseClientRPCStats(filterNFSLines(bytesData, nfsClientLines))
+}
+
+func (parser *LinuxParser) ParseNFSServerStat(bytesData []byte) (*nfs.ServerRPCStats, error) {
+	return nfs.ParseServerRPCStats(filterNFSLines(bytesData, nfsServerLines))
+}
+
+func filterNFSLines(bytesData []byte, known map[string]bool) *bytes.Buffer {
+	var (
+		filtered = &bytes.Buffer{}
+		scanner  = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.Fields(line)
+		if len(parts) == 0 || !known[parts[0]] {
+			continue
+		}
+		filtered.WriteString(line)
+		filtered.WriteByte('\n')
+	}
+
+	return filtered
+}
+
+func (parser *LinuxParser) ParseNFSMountStats() ([]NFSMountStat, error) {
+	fs, err := procfs.NewFS(procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := fs.Self()
+	if err != nil {
+		return nil, err
+	}
+
+	mounts, err := proc.MountStats()
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []NFSMountStat
+	for _, mount := range mounts {
+		nfsStats, ok := mount.Stats.(*procfs.MountStatsNFS)
+		if !ok {
+			continue
+		}
+		stats = append(stats, NFSMountStat{
+			Export:        mount.Device,
+			MountPoint:    mount.Mount,
+			MountStatsNFS: nfsStats,
+		})
+	}
+
+	return stats, nil
+}
+
+func (parser *LinuxParser) ParseNFSDPoolStats(bytesData []byte) ([]NFSDPoolStat, error) {
+	var (
+		header  []string
+		stats   []NFSDPoolStat
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+
+		// # pool packets-arrived sockets-enqueued threads-woken threads-timedout
+		if parts[0] == "#" {
+			header = parts[1:]
+			continue
+		}
+		if len(parts) != len(header) {
+			return nil, fmt.Errorf("invalid line in nfsd pool_stats: %s", scanner.Text())
+		}
+
+		stat := NFSDPoolStat{Pool: parts[0], Values: make(map[string]float64, len(parts)-1)}
+		for i, part := range parts[1:] {
+			value, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s for %s in nfsd pool_stats: %w", part, header[i+1], err)
+			}
+			stat.Values[header[i+1]] = value
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, scanner.Err()
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Q_GETNEXTQUOTA from linux/quota.h, available since Linux 4.6.
+	quotaGetNext = 0x800009
+	// Block limits are reported in units of QIF_DQBLKSIZE.
+	quotaBlockSize = 1024
+)
+
+// quotaTypes are the quota types of linux/quota.h in order of their value.
+var quotaTypes = []string{"user", "group", "project"}
+
+// ifNextDQBlk mirrors struct if_nextdqblk of linux/quota.h.
+type ifNextDQBlk struct {
+	BHardLimit uint64
+	BSoftLimit uint64
+	CurSpace   uint64
+	IHardLimit uint64
+	ISoftLimit uint64
+	CurInodes  uint64
+	BTime      uint64
+	ITime      uint64
+	Valid      uint32
+	ID         uint32
+}
+
+// ParseQuotas returns the quotas of all ids that have one on the filesystem
+// held by the block device. Quota types that are not enabled are skipped.
+func (parser *LinuxParser) ParseQuotas(device string) ([]Quota, error) {
+	special, err := unix.BytePtrFromString(rootfsFilePath(device))
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []Quota
+	for quotaType, typeName := range quotaTypes {
+		cmd := quotaGetNext<<8 | quotaType
+		for id := uint32(0); ; {
+			var block ifNextDQBlk
+			_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, uintptr(cmd), uintptr(unsafe.Pointer(special)),
+				uintptr(id), uintptr(unsafe.Pointer(&block)), 0, 0)
+			// ENOENT marks the end of the ids, ESRCH a quota type that is
+			// not enabled on this filesystem.
+			if errno == unix.ENOENT || errno == unix.ESRCH {
+				break
+			}
+			if errno != 0 {
+				return nil, fmt.Errorf("quotactl %s %s: %w", typeName, device, errno)
+			}
+
+			quotas = append(quotas, Quota{
+				Type:            typeName,
+				ID:              block.ID,
+				BlocksUsed:      float64(block.CurSpace),
+				BlocksSoftLimit: float64(block.BSoftLimit * quotaBlockSize),
+				BlocksHardLimit: float64(block.BHardLimit * quotaBlockSize),
+				InodesUsed:      float64(block.CurInodes),
+				InodesSoftLimit: float64(block.ISoftLimit),
+				InodesHardLimit: float64(block.IHardLimit),
+			})
+
+			if block.ID == ^uint32(0) {
+				break
+			}
+			id = block.ID + 1
+		}
+	}
+
+	return quotas, nil
+}
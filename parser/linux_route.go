@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// routeTableNames names the reserved tables of linux/rtnetlink.h the way
+// /etc/iproute2/rt_tables does.
+var routeTableNames = map[uint32]string{
+	unix.RT_TABLE_DEFAULT: "default",
+	unix.RT_TABLE_MAIN:    "main",
+	unix.RT_TABLE_LOCAL:   "local",
+}
+
+// ParseRoutes dumps the IPv4 and IPv6 routes of all tables with a single
+// RTM_GETROUTE request. Cached IPv6 routes are left out.
+func (parser *LinuxParser) ParseRoutes() ([]Route, error) {
+	names, err := netLinkNames()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := syscall.NetlinkRIB(unix.RTM_GETROUTE, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("netlink dump: %w", err)
+	}
+	return parseRouteMessages(data, names)
+}
+
+func parseRouteMessages(data []byte, names map[uint32]string) ([]Route, error) {
+	messages, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]Route, 0, len(messages))
+	for _, message := range messages {
+		if message.Header.Type == unix.NLMSG_ERROR && len(message.Data) >= 4 {
+			errno := int32(nativeEndian.Uint32(message.Data))
+			return nil, fmt.Errorf("netlink dump: %w", syscall.Errno(-errno))
+		}
+		if message.Header.Type != unix.RTM_NEWROUTE || len(message.Data) < unix.SizeofRtMsg {
+			continue
+		}
+
+		// struct rtmsg: family, dst_len, src_len, tos, table, protocol,
+		// scope, type and flags.
+		msg := message.Data
+		if nativeEndian.Uint32(msg[8:12])&unix.RTM_F_CLONED != 0 {
+			continue
+		}
+
+		var family string
+		switch msg[0] {
+		case unix.AF_INET:
+			family = "ipv4"
+		case unix.AF_INET6:
+			family = "ipv6"
+		default:
+			continue
+		}
+
+		attrs, err := syscall.ParseNetlinkRouteAttr(&message)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			table   = uint32(msg[4])
+			nextHop RouteNextHop
+			route   = Route{
+				Family:  family,
+				Default: msg[1] == 0 && msg[7] == unix.RTN_UNICAST,
+			}
+		)
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case unix.RTA_TABLE:
+				// The table id of rtmsg only has 8 bits.
+				if len(attr.Value) >= 4 {
+					table = nativeEndian.Uint32(attr.Value)
+				}
+			case unix.RTA_PRIORITY:
+				if len(attr.Value) >= 4 {
+					route.Metric = nativeEndian.Uint32(attr.Value)
+				}
+			case unix.RTA_OIF:
+				if len(attr.Value) >= 4 {
+					nextHop.Device = names[nativeEndian.Uint32(attr.Value)]
+				}
+			case unix.RTA_GATEWAY:
+				nextHop.Gateway = net.IP(attr.Value).String()
+			case unix.RTA_MULTIPATH:
+				route.NextHops = append(route.NextHops, parseRouteNextHops(attr.Value, names)...)
+			}
+		}
+		if nextHop.Device != "" || nextHop.Gateway != "" {
+			route.NextHops = append(route.NextHops, nextHop)
+		}
+
+		route.Table = routeTableNames[table]
+		if route.Table == "" {
+			route.Table = strconv.FormatUint(uint64(table), 10)
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// parseRouteNextHops splits the payload of RTA_MULTIPATH into the struct
+// rtnexthop of each path, each followed by its own attributes.
+func parseRouteNextHops(b []byte, names map[uint32]string) []RouteNextHop {
+	var nextHops []RouteNextHop
+	for len(b) >= unix.SizeofRtNexthop {
+		length := int(nativeEndian.Uint16(b[0:2]))
+		if length < unix.SizeofRtNexthop || length > len(b) {
+			break
+		}
+
+		nextHop := RouteNextHop{Device: names[nativeEndian.Uint32(b[4:8])]}
+		for _, attr := range parseNestedAttrs(b[unix.SizeofRtNexthop:length]) {
+			if attr.Attr.Type == unix.RTA_GATEWAY {
+				nextHop.Gateway = net.IP(attr.Value).String()
+			}
+		}
+		nextHops = append(nextHops, nextHop)
+
+		aligned := (length + unix.RTA_ALIGNTO - 1) &^ (unix.RTA_ALIGNTO - 1)
+		if aligned > len(b) {
+			break
+		}
+		b = b[aligned:]
+	}
+	return nextHops
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// netLinkTestNames are the devices of the host the route and neighbour
+// fixtures were recorded on.
+var netLinkTestNames = map[uint32]string{1: "lo", 4: "eth0"}
+
+func TestParseRouteMessages(t *testing.T) {
+	// Recorded from a 6.18 kernel with a multipath route and a default
+	// route in table 1000, which does not fit the table id of rtmsg.
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "netlink", "routes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes, err := parseRouteMessages(data, netLinkTestNames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(routes) != 16 {
+		t.Fatalf("want 16 routes, got %d", len(routes))
+	}
+
+	tables := map[string]int{}
+	var defaults []Route
+	for _, route := range routes {
+		tables[route.Family+"/"+route.Table]++
+		if route.Default {
+			defaults = append(defaults, route)
+		}
+	}
+
+	wantTables := map[string]int{"ipv4/1000": 1, "ipv4/main": 3, "ipv4/local": 5, "ipv6/main": 3, "ipv6/local": 4}
+	if !reflect.DeepEqual(tables, wantTables) {
+		t.Errorf("got tables %v, want %v", tables, wantTables)
+	}
+
+	wantDefaults := []Route{
+		{Family: "ipv4", Table: "1000", Default: true, Metric: 100, NextHops: []RouteNextHop{{Gateway: "192.0.2.1", Device: "eth0"}}},
+		{Family: "ipv4", Table: "main", Default: true, NextHops: []RouteNextHop{{Gateway: "192.0.2.1", Device: "eth0"}}},
+		{Family: "ipv6", Table: "main", Default: true, Metric: 1024, NextHops: []RouteNextHop{{Gateway: "fd00::1", Device: "eth0"}}},
+	}
+	if !reflect.DeepEqual(defaults, wantDefaults) {
+		t.Errorf("got default routes %+v, want %+v", defaults, wantDefaults)
+	}
+
+	wantMultipath := []RouteNextHop{{Gateway: "192.0.2.1", Device: "eth0"}, {Gateway: "192.0.2.3", Device: "eth0"}}
+	if !reflect.DeepEqual(routes[3].NextHops, wantMultipath) {
+		t.Errorf("got multipath next hops %+v, want %+v", routes[3].NextHops, wantMultipath)
+	}
+}
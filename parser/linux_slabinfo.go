@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseSlabInfo() ([]SlabInfo, error) {
+	// slabinfo is only readable by root, the error is returned unwrapped so
+	// callers can tell a permission problem from a parse failure.
+	bytesData, err := ioutil.ReadFile(procFilePath("slabinfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSlabInfo(bytesData)
+}
+
+func parseSlabInfo(bytesData []byte) ([]SlabInfo, error) {
+	scanner := bufio.NewScanner(bytes.NewBuffer(bytesData))
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("slabinfo empty")
+	}
+	// The layout below is the one of "slabinfo - version: 2.1".
+	header := scanner.Text()
+	if version := strings.TrimSpace(strings.TrimPrefix(header, "slabinfo - version:")); version != "2.1" {
+		return nil, fmt.Errorf("unsupported slabinfo header: %s", header)
+	}
+
+	slabs := make([]SlabInfo, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		// name <active_objs> <num_objs> <objsize> <objperslab> <pagesperslab>
+		//   : tunables <limit> <batchcount> <sharedfactor>
+		//   : slabdata <active_slabs> <num_slabs> <sharedavail>
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) != 16 {
+			return nil, fmt.Errorf("invalid line in slabinfo: %s", line)
+		}
+
+		// The numbers before tunables, then active_slabs and num_slabs.
+		fields := []string{parts[1], parts[2], parts[3], parts[4], parts[5], parts[13], parts[14]}
+		values := make([]float64, 0, len(fields))
+		for _, part := range fields {
+			fv, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s in slabinfo: %w", part, err)
+			}
+			values = append(values, fv)
+		}
+
+		slabs = append(slabs, SlabInfo{
+			Name:           parts[0],
+			ActiveObjects:  values[0],
+			Objects:        values[1],
+			ObjectSize:     values[2],
+			ObjectsPerSlab: values[3],
+			PagesPerSlab:   values[4],
+			ActiveSlabs:    values[5],
+			Slabs:          values[6],
+		})
+	}
+
+	return slabs, scanner.Err()
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSlabInfo(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "slabinfo", "v2.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parseSlabInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SlabInfo{
+		{Name: "ext4_groupinfo_4k", ActiveObjects: 2054, Objects: 2054, ObjectSize: 152, ObjectsPerSlab: 26, PagesPerSlab: 1, ActiveSlabs: 79, Slabs: 79},
+		{Name: "dentry", ActiveObjects: 94563, Objects: 101178, ObjectSize: 192, ObjectsPerSlab: 21, PagesPerSlab: 1, ActiveSlabs: 4818, Slabs: 4818},
+		{Name: "kmalloc-8k", ActiveObjects: 108, Objects: 116, ObjectSize: 8192, ObjectsPerSlab: 4, PagesPerSlab: 8, ActiveSlabs: 29, Slabs: 29},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestParseSlabInfoInvalid(t *testing.T) {
+	for _, fixture := range []string{"v2.0", "malformed"} {
+		t.Run(fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "slabinfo", fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := parseSlabInfo(data); err == nil {
+				t.Errorf("expected an error for %s", fixture)
+			}
+		})
+	}
+}
+
+func TestParseSlabInfoPermission(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can read files without read permission")
+	}
+
+	dir, err := ioutil.TempDir("", "slabinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "slabinfo"), nil, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(path string) { procPath = path }(procPath)
+	procPath = dir
+
+	parser := &LinuxParser{}
+	if _, err := parser.ParseSlabInfo(); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("want a permission error, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSockStat parses /proc/net/sockstat and /proc/net/sockstat6, where each
+// line holds a protocol followed by name and value pairs:
+//
+//	TCP: inuse 51 orphan 2 tw 189 alloc 76 mem 12
+func (parser *LinuxParser) ParseSockStat(bytesData []byte) (SockStat, error) {
+	var (
+		sockStat = SockStat{}
+		scanner  = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts)%2 != 1 || !strings.HasSuffix(parts[0], ":") {
+			return nil, fmt.Errorf("invalid line in sockstat: %s", scanner.Text())
+		}
+
+		protocol := strings.TrimSuffix(parts[0], ":")
+		fields := make(map[string]float64, len(parts)/2)
+		for i := 1; i < len(parts); i += 2 {
+			value, err := strconv.ParseFloat(parts[i+1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s for %s %s in sockstat: %w", parts[i+1], protocol, parts[i], err)
+			}
+			fields[parts[i]] = value
+		}
+		sockStat[protocol] = fields
+	}
+
+	return sockStat, scanner.Err()
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSockStat(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    SockStat
+	}{
+		{
+			// No UDPLITE and no UDP memory accounting.
+			fixture: "linux-2.6.9",
+			want: SockStat{
+				"sockets": {"used": 229},
+				"TCP":     {"inuse": 4, "orphan": 0, "tw": 4, "alloc": 17, "mem": 1},
+				"UDP":     {"inuse": 0},
+				"RAW":     {"inuse": 0},
+				"FRAG":    {"inuse": 0, "memory": 0},
+			},
+		},
+		{
+			fixture: "linux-5.10",
+			want: SockStat{
+				"sockets": {"used": 1074},
+				"TCP":     {"inuse": 51, "orphan": 2, "tw": 189, "alloc": 76, "mem": 12},
+				"UDP":     {"inuse": 6, "mem": 3},
+				"UDPLITE": {"inuse": 0},
+				"RAW":     {"inuse": 1},
+				"FRAG":    {"inuse": 0, "memory": 0},
+			},
+		},
+		{
+			fixture: "sockstat6",
+			want: SockStat{
+				"TCP6":     {"inuse": 17},
+				"UDP6":     {"inuse": 9},
+				"UDPLITE6": {"inuse": 0},
+				"RAW6":     {"inuse": 1},
+				"FRAG6":    {"inuse": 0, "memory": 0},
+			},
+		},
+	}
+
+	parser := &LinuxParser{}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "sockstat", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parser.ParseSockStat(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseSockStatInvalid(t *testing.T) {
+	parser := &LinuxParser{}
+	for _, data := range []string{"TCP: inuse\n", "TCP: inuse x\n", "TCP inuse 1\n"} {
+		if _, err := parser.ParseSockStat([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
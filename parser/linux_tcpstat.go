@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// SOCK_DIAG_BY_FAMILY from linux/sock_diag.h.
+	sockDiagByFamily = 20
+	// Size of struct inet_diag_msg of linux/inet_diag.h.
+	sizeofInetDiagMsg = 72
+)
+
+// tcpStates names the TCP states of include/net/tcp_states.h by their value.
+var tcpStates = map[uint8]string{
+	1:  "established",
+	2:  "syn_sent",
+	3:  "syn_recv",
+	4:  "fin_wait1",
+	5:  "fin_wait2",
+	6:  "time_wait",
+	7:  "close",
+	8:  "close_wait",
+	9:  "last_ack",
+	10: "listen",
+	11: "closing",
+	// Request sockets, which /proc/net/tcp lists as syn_recv.
+	12: "syn_recv",
+}
+
+// inetDiagReqV2 mirrors struct inet_diag_req_v2 of linux/inet_diag.h.
+type inetDiagReqV2 struct {
+	Family   uint8
+	Protocol uint8
+	Ext      uint8
+	Pad      uint8
+	States   uint32
+	// struct inet_diag_sockid, unused for a dump of all sockets.
+	ID [48]byte
+}
+
+type tcpDiagRequest struct {
+	Header unix.NlMsghdr
+	Req    inetDiagReqV2
+}
+
+// ParseTCPStat counts the TCP sockets of both address families with a
+// NETLINK_SOCK_DIAG dump. Sockets bound to one of ports are also counted per
+// local port.
+func (parser *LinuxParser) ParseTCPStat(ports map[uint16]bool) (TCPStat, error) {
+	stat := newTCPStat()
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		data, err := tcpDiagDump(family)
+		if err != nil {
+			return stat, err
+		}
+
+		familyStat, err := parseTCPDiagMessages(data, ports)
+		if err != nil {
+			return stat, err
+		}
+		stat.Merge(familyStat)
+	}
+	return stat, nil
+}
+
+// tcpDiagDump returns the messages of a sock_diag dump of the TCP sockets of
+// family, up to and including NLMSG_DONE or NLMSG_ERROR.
+func tcpDiagDump(family uint8) ([]byte, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_INET_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("sock_diag socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	request := tcpDiagRequest{
+		Header: unix.NlMsghdr{
+			Len:   uint32(unsafe.Sizeof(tcpDiagRequest{})),
+			Type:  sockDiagByFamily,
+			Flags: unix.NLM_F_REQUEST | unix.NLM_F_DUMP,
+			Seq:   1,
+		},
+		Req: inetDiagReqV2{
+			Family:   family,
+			Protocol: unix.IPPROTO_TCP,
+			States:   ^uint32(0),
+		},
+	}
+	requestBytes := (*[unsafe.Sizeof(tcpDiagRequest{})]byte)(unsafe.Pointer(&request))[:]
+	if err := unix.Sendto(fd, requestBytes, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("sock_diag request: %w", err)
+	}
+
+	var (
+		data []byte
+		buf  = make([]byte, 32*1024)
+	)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, fmt.Errorf("sock_diag receive: %w", err)
+		}
+		data = append(data, buf[:n]...)
+
+		messages, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if message.Header.Type == unix.NLMSG_DONE || message.Header.Type == unix.NLMSG_ERROR {
+				return data, nil
+			}
+		}
+	}
+}
+
+func parseTCPDiagMessages(data []byte, ports map[uint16]bool) (TCPStat, error) {
+	stat := newTCPStat()
+	messages, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return stat, err
+	}
+
+	for _, message := range messages {
+		switch message.Header.Type {
+		case unix.NLMSG_ERROR:
+			if len(message.Data) >= 4 {
+				errno := int32(nativeEndian.Uint32(message.Data))
+				return stat, fmt.Errorf("sock_diag dump: %w", syscall.Errno(-errno))
+			}
+			return stat, fmt.Errorf("sock_diag dump failed")
+		case sockDiagByFamily:
+			if len(message.Data) < sizeofInetDiagMsg {
+				continue
+			}
+			// struct inet_diag_msg: family, state, timer, retrans, then
+			// the socket id starting with the big endian source port.
+			// The receive and send queues follow the 48 byte id and
+			// expires.
+			msg := message.Data
+			port := uint16(msg[4])<<8 | uint16(msg[5])
+			rx := nativeEndian.Uint32(msg[56:60])
+			tx := nativeEndian.Uint32(msg[60:64])
+			stat.add(msg[1], port, float64(tx), float64(rx), ports)
+		}
+	}
+
+	return stat, nil
+}
+
+// ParseProcNetTCP counts the sockets listed in /proc/net/tcp or /proc/net/tcp6.
+func (parser *LinuxParser) ParseProcNetTCP(bytesData []byte, ports map[uint16]bool) (TCPStat, error) {
+	var (
+		stat    = newTCPStat()
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	scanner.Scan() // skip header
+	for scanner.Scan() {
+		//  sl  local_address rem_address   st tx_queue:rx_queue ...
+		//   0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 ...
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 5 {
+			return stat, fmt.Errorf("invalid line in net/tcp: %s", scanner.Text())
+		}
+
+		localAddress := strings.Split(parts[1], ":")
+		queues := strings.Split(parts[4], ":")
+		if len(localAddress) != 2 || len(queues) != 2 {
+			return stat, fmt.Errorf("invalid line in net/tcp: %s", scanner.Text())
+		}
+
+		port, err := strconv.ParseUint(localAddress[1], 16, 16)
+		if err != nil {
+			return stat, fmt.Errorf("invalid local port %s in net/tcp: %w", localAddress[1], err)
+		}
+		state, err := strconv.ParseUint(parts[3], 16, 8)
+		if err != nil {
+			return stat, fmt.Errorf("invalid state %s in net/tcp: %w", parts[3], err)
+		}
+		tx, err := strconv.ParseUint(queues[0], 16, 64)
+		if err != nil {
+			return stat, fmt.Errorf("invalid tx_queue %s in net/tcp: %w", queues[0], err)
+		}
+		rx, err := strconv.ParseUint(queues[1], 16, 64)
+		if err != nil {
+			return stat, fmt.Errorf("invalid rx_queue %s in net/tcp: %w", queues[1], err)
+		}
+
+		stat.add(uint8(state), uint16(port), float64(tx), float64(rx), ports)
+	}
+
+	return stat, scanner.Err()
+}
+
+func newTCPStat() TCPStat {
+	return TCPStat{
+		States: map[string]*TCPStateStat{},
+		Ports:  map[uint16]map[string]*TCPStateStat{},
+	}
+}
+
+func (stat TCPStat) add(state uint8, port uint16, tx, rx float64, ports map[uint16]bool) {
+	name, ok := tcpStates[state]
+	if !ok {
+		name = strconv.Itoa(int(state))
+	}
+
+	stat.States[name] = stat.States[name].add(tx, rx)
+	if ports[port] {
+		if stat.Ports[port] == nil {
+			stat.Ports[port] = map[string]*TCPStateStat{}
+		}
+		stat.Ports[port][name] = stat.Ports[port][name].add(tx, rx)
+	}
+}
+
+func (s *TCPStateStat) add(tx, rx float64) *TCPStateStat {
+	if s == nil {
+		s = &TCPStateStat{}
+	}
+	s.Count++
+	s.TransmitQueue += tx
+	s.ReceiveQueue += rx
+	return s
+}
+
+// Merge adds the counts of other, e.g. to combine /proc/net/tcp and tcp6.
+func (stat TCPStat) Merge(other TCPStat) {
+	for state, s := range other.States {
+		stat.States[state] = stat.States[state].merge(s)
+	}
+	for port, states := range other.Ports {
+		if stat.Ports[port] == nil {
+			stat.Ports[port] = map[string]*TCPStateStat{}
+		}
+		for state, s := range states {
+			stat.Ports[port][state] = stat.Ports[port][state].merge(s)
+		}
+	}
+}
+
+func (s *TCPStateStat) merge(other *TCPStateStat) *TCPStateStat {
+	if s == nil {
+		s = &TCPStateStat{}
+	}
+	s.Count += other.Count
+	s.TransmitQueue += other.TransmitQueue
+	s.ReceiveQueue += other.ReceiveQueue
+	return s
+}
@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseVMStat(bytesData []byte) (VMStat, error) {
+	var (
+		vmStat  = VMStat{}
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid line in vmstat: %s", scanner.Text())
+		}
+		value, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %s in vmstat: %w", parts[1], err)
+		}
+		vmStat[parts[0]] = value
+	}
+
+	return vmStat, scanner.Err()
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (parser *LinuxParser) ParseXFSStat(bytesData []byte) (XFSStat, error) {
+	var (
+		xfsStat = XFSStat{}
+		scanner = bufio.NewScanner(bytes.NewBuffer(bytesData))
+	)
+
+	for scanner.Scan() {
+		// extent_alloc 92447 97589 92448 93751
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 2 {
+			continue
+		}
+
+		values := make([]float64, 0, len(parts)-1)
+		for _, part := range parts[1:] {
+			value, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s for %s in xfs stat: %w", part, parts[0], err)
+			}
+			values = append(values, value)
+		}
+		xfsStat[parts[0]] = values
+	}
+
+	return xfsStat, scanner.Err()
+}
+
+func (parser *LinuxParser) ParseXFSDeviceStats() (map[string]XFSStat, error) {
+	files, err := filepath.Glob(parser.sysFSPath("fs", "xfs", "*", "stats", "stats"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]XFSStat, len(files))
+	for _, file := range files {
+		bytesData, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		// /sys/fs/xfs/<device>/stats/stats
+		device := filepath.Base(filepath.Dir(filepath.Dir(file)))
+		if stats[device], err = parser.ParseXFSStat(bytesData); err != nil {
+			return nil, fmt.Errorf("xfs device %s: %w", device, err)
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseXFSDeviceStats(t *testing.T) {
+	parser, err := newLinuxParser(filepath.Join("testdata", "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parser.ParseXFSDeviceStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fs/xfs/stats holds the global counters, not those of a device.
+	want := map[string]XFSStat{
+		"dm-0": {
+			"extent_alloc": {92447, 97589, 92448, 93751},
+			"abt":          {0, 0, 0, 0},
+			"xpc":          {399724544, 92823103, 86219234},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
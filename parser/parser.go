@@ -1,35 +1,87 @@
 package parser
 
-import "github.com/prometheus/procfs/sysfs"
+import (
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/procfs"
+	"github.com/prometheus/procfs/btrfs"
+	"github.com/prometheus/procfs/nfs"
+	"github.com/prometheus/procfs/sysfs"
+)
 
 type Parser interface {
 	ParseCPUStat([]byte) (Stat, error)
 	ParseDiskStat([]byte) (DiskStat, error)
-	ParseFileSystemStat([]byte) ([]FileSystemStat, error)
-	ParseIPStat() (IPStat, error)
+	ParseFileSystemStat([]byte, FileSystemOptions) ([]FileSystemStat, error)
+	ParseFileSystemMounts([]byte, FileSystemOptions) ([]FileSystemLabels, error)
+	ParseIPStat(NetDevFilter) (IPStat, error)
 	ParseLoadAvgStat([]byte) (LoadAvgStat, error)
 	ParseMemoryStat([]byte) (MemoryStat, error)
-	ParseNetStat([]byte) (NetStat, error)
+	ParseNetStat([]byte, NetDevFilter) (NetStat, error)
 	ParseUname() (UnameStat, error)
 	ParseBootTime() (float64, error)
-	ParseNetClass() (sysfs.NetClass, error)
+	ParseNetClass(NetDevFilter) (NetClass, error)
+	ParseNetLinks(NetDevFilter) ([]NetLink, error)
+	ParseARP(NetDevFilter) ([]ARPEntry, error)
+	ParseRoutes() ([]Route, error)
+	ParseBonding(NetDevFilter) ([]Bond, error)
+	ParseBridges(NetDevFilter) ([]Bridge, error)
+	ParseEthtool(NetDevFilter) ([]Ethtool, error)
+	ParseSockStat([]byte) (SockStat, error)
+	ParseTCPStat(map[uint16]bool) (TCPStat, error)
+	ParseProcNetTCP([]byte, map[uint16]bool) (TCPStat, error)
 	ParseNetStatInfo() (map[string]map[string]string, error)
+	ParseConntrack() (Conntrack, error)
+	ParseConntrackStat([]byte) ([]ConntrackStat, error)
 	ParseFileFDStat() (map[string]string, error)
+	ParseVMStat([]byte) (VMStat, error)
+	ParseSoftIRQs([]byte) (SoftIRQs, error)
+	ParseInterrupts([]byte) (Interrupts, error)
+	ParseNUMAMemoryStat() ([]NUMAMemoryStat, error)
+	ParseBuddyInfo([]byte) ([]BuddyInfo, error)
+	ParseZoneInfo([]byte) ([]ZoneInfo, error)
+	ParseSlabInfo() ([]SlabInfo, error)
+	ParseBlockDevices() ([]BlockDevice, error)
+	ParseMDStat([]byte) ([]MDStat, error)
+	ParseMDArray(string) (MDArray, error)
+	ParseMountInfo([]byte) ([]MountInfo, error)
+	ParseBtrfsStats() ([]BtrfsStat, error)
+	ParseXFSStat([]byte) (XFSStat, error)
+	ParseXFSDeviceStats() (map[string]XFSStat, error)
+	ParseExt4Stats() ([]Ext4Stat, error)
+	ParseNFSClientStat([]byte) (*nfs.ClientRPCStats, error)
+	ParseNFSServerStat([]byte) (*nfs.ServerRPCStats, error)
+	ParseNFSMountStats() ([]NFSMountStat, error)
+	ParseNFSDPoolStats([]byte) ([]NFSDPoolStat, error)
+	ParseQuotas(string) ([]Quota, error)
 }
 
 var sysPath = "/sys"
 
 type LinuxParser struct {
 	fs sysfs.FS
+	// Mount point of fs, to resolve the sysfs files it has no reader for.
+	sysMountPoint string
 }
 
 func NewLinuxParser() (*LinuxParser, error) {
-	fs, err := sysfs.NewFS(sysPath)
+	return newLinuxParser(sysPath)
+}
+
+func newLinuxParser(sysMountPoint string) (*LinuxParser, error) {
+	fs, err := sysfs.NewFS(sysMountPoint)
 	if err != nil {
 		return nil, err
 	}
 
-	return &LinuxParser{fs: fs}, nil
+	return &LinuxParser{fs: fs, sysMountPoint: sysMountPoint}, nil
+}
+
+// sysFSPath returns the path of a file below the sysfs mount point of the parser.
+func (parser *LinuxParser) sysFSPath(elem ...string) string {
+	return filepath.Join(append([]string{parser.sysMountPoint}, elem...)...)
 }
 
 // CPUStat shows how much time the cpu spend in various stages.
@@ -48,7 +100,7 @@ type CPUStat struct {
 
 // SoftIRQStat represent the softirq statistics as exported in the procfs stat file.
 // A nice introduction can be found at https://0xax.gitbooks.io/linux-insides/content/interrupts/interrupts-9.html
-// It is possible to get per-cpu stats by reading /proc/softirqs
+// Per-CPU stats are read from /proc/softirqs by ParseSoftIRQs.
 type SoftIRQStat struct {
 	Hi          uint64
 	Timer       uint64
@@ -88,7 +140,95 @@ type Stat struct {
 	SoftIRQ SoftIRQStat
 }
 
-type DiskStat map[string][]string
+// DiskStat maps block device names to their statistics.
+type DiskStat map[string]DiskDeviceStat
+
+// DiskDeviceStat holds the /proc/diskstats counters of a block device, with
+// sectors converted to bytes and milliseconds to seconds.
+type DiskDeviceStat struct {
+	// Number of statistic columns the kernel reported: 4, 11, 15 or 17.
+	// Fields beyond that count are left at zero.
+	Fields int
+
+	ReadsCompleted   float64
+	ReadsMerged      float64
+	ReadBytes        float64
+	ReadTimeSeconds  float64
+	WritesCompleted  float64
+	WritesMerged     float64
+	WrittenBytes     float64
+	WriteTimeSeconds float64
+
+	// Number of I/Os currently in progress.
+	IONow                 float64
+	IOTimeSeconds         float64
+	IOTimeWeightedSeconds float64
+
+	DiscardsCompleted  float64
+	DiscardsMerged     float64
+	DiscardedSectors   float64
+	DiscardTimeSeconds float64
+
+	FlushRequests            float64
+	FlushRequestsTimeSeconds float64
+}
+
+// BlockDevice holds the identity and queue attributes of /sys/block/<dev>.
+// Attributes the device does not provide are left empty or nil.
+type BlockDevice struct {
+	Name       string
+	Model      string
+	Serial     string
+	WWN        string
+	Revision   string
+	Rotational string
+	// Device-mapper name, e.g. the LVM logical volume.
+	DMName string
+	// md arrays the device or one of its partitions is a member of.
+	MDArrays  []string
+	Scheduler string
+
+	Size              *float64
+	LogicalBlockSize  *float64
+	PhysicalBlockSize *float64
+	NrRequests        *float64
+	QueueDepth        *float64
+}
+
+// MDStat holds the state of a software RAID array from /proc/mdstat.
+type MDStat struct {
+	Name string
+	// active or inactive, or the sync operation in progress: recovering,
+	// resync, reshape or check.
+	State string
+	// Personality such as raid1 or raid10, empty for inactive arrays and containers.
+	Level   string
+	Devices []string
+
+	DisksActive float64
+	DisksFailed float64
+	DisksSpare  float64
+	// Number of disks the array requires.
+	DisksTotal float64
+
+	BlocksTotal float64
+	// Blocks already processed by the running sync operation, the total
+	// when the array is in sync.
+	BlocksSynced float64
+	// Progress of the running sync operation from 0 to 1.
+	SyncCompletion float64
+}
+
+// MDArray holds the attributes of /sys/block/<md>/md.
+// Attributes the kernel does not provide are left empty or nil.
+type MDArray struct {
+	ArrayState string
+	SyncAction string
+	// Number of missing or failed devices.
+	Degraded *float64
+	// Sectors found inconsistent by the last check or repair.
+	MismatchCount *float64
+}
 
 type FileSystemStat struct {
 	Labels            FileSystemLabels
@@ -97,18 +237,299 @@ type FileSystemStat struct {
 	Ro, DeviceError   float64
 }
 
+// NetDevFilter selects network devices by name. Nil patterns do not filter.
+type NetDevFilter struct {
+	Include *regexp.Regexp
+	Exclude *regexp.Regexp
+}
+
+// FileSystemOptions selects the mounts ParseFileSystemStat reports on and
+// bounds how statfs is called. Nil patterns do not filter.
+type FileSystemOptions struct {
+	MountPointsInclude *regexp.Regexp
+	MountPointsExclude *regexp.Regexp
+	FSTypesInclude     *regexp.Regexp
+	FSTypesExclude     *regexp.Regexp
+	// Time after which a statfs call marks the mount as stuck.
+	MountTimeout time.Duration
+	// Number of concurrent statfs calls.
+	StatWorkers int
+}
+
 type FileSystemLabels struct {
 	Device, MountPoint, FsType, Options string
 }
 
+// MountInfo holds a line of /proc/<pid>/mountinfo.
+type MountInfo struct {
+	MountID    string
+	ParentID   string
+	MajorMinor string
+	Root       string
+	MountPoint string
+	Options    string
+	// Optional fields such as "shared:1 master:2", empty for private mounts.
+	Propagation string
+	FsType      string
+	Source      string
+	// Name of the block device behind MajorMinor, empty for virtual filesystems.
+	BlockDevice string
+}
+
+// BtrfsStat holds the sysfs statistics of a btrfs filesystem.
+type BtrfsStat struct {
+	*btrfs.Stats
+	// Error counters by type (write, read, flush, corruption, generation),
+	// keyed by device id. Empty on kernels before 5.14.
+	DeviceErrors map[string]map[string]float64
+}
+
+// XFSStat maps the lines of an xfs stats file to their values.
+type XFSStat map[string][]float64
+
+// Ext4Stat holds the error counters of /sys/fs/ext4/<device>.
+type Ext4Stat struct {
+	Device string
+	Values map[string]float64
+}
+
+// NFSMountStat holds the /proc/self/mountstats statistics of an NFS mount.
+type NFSMountStat struct {
+	// Remote export, e.g. server:/home.
+	Export     string
+	MountPoint string
+	*procfs.MountStatsNFS
+}
+
+// NFSDPoolStat holds the counters of an nfsd thread pool from
+// /proc/fs/nfsd/pool_stats, keyed by the column names of the header.
+type NFSDPoolStat struct {
+	Pool   string
+	Values map[string]float64
+}
+
+// Quota holds the usage and limits of a user, group or project quota.
+// Block values are in bytes, a limit of 0 means no limit.
+type Quota struct {
+	Type            string
+	ID              uint32
+	BlocksUsed      float64
+	BlocksSoftLimit float64
+	BlocksHardLimit float64
+	InodesUsed      float64
+	InodesSoftLimit float64
+	InodesHardLimit float64
+}
+
 type IPStat []string
 
 type LoadAvgStat []float64
 
 type MemoryStat map[string]float64
 
+// BuddyInfo holds the free blocks of a memory zone, indexed by allocation order.
+type BuddyInfo struct {
+	Node   string
+	Zone   string
+	Blocks []float64
+}
+
+// ZoneInfo holds the page counts of a memory zone from /proc/zoneinfo.
+type ZoneInfo struct {
+	Node string
+	Zone string
+	// Free pages, watermarks (min, low, high, ...) and spanned/present/managed pages.
+	Pages map[string]float64
+	// Pages held back from allocations that may fall back from a higher zone,
+	// keyed by the name of that zone.
+	Protection map[string]float64
+}
+
+// SlabInfo holds the statistics of a single slab cache from /proc/slabinfo.
+type SlabInfo struct {
+	Name           string
+	ActiveObjects  float64
+	Objects        float64
+	ObjectSize     float64
+	ObjectsPerSlab float64
+	PagesPerSlab   float64
+	ActiveSlabs    float64
+	Slabs          float64
+}
+
+// NUMAMemoryStat holds the meminfo and numastat fields of a single NUMA node.
+type NUMAMemoryStat struct {
+	Node     string
+	MemInfo  map[string]float64
+	NUMAStat map[string]float64
+}
+
 type NetStat map[string]map[string]uint64
 
+// NetClass maps network device names to their /sys/class/net attributes.
+type NetClass map[string]NetClassIface
+
+type NetClassIface struct {
+	sysfs.NetClassIface
+	// Kernel driver bound to the device, empty for virtual devices.
+	Driver string
+}
+
+// Bond holds the state of a bonding master from /sys/class/net/<bond>/bonding.
+type Bond struct {
+	Name      string
+	Mode      string
+	MIIStatus string
+	Slaves    []BondSlave
+}
+
+// BondSlave holds the state of a bond slave from /sys/class/net/<slave>/bonding_slave.
+type BondSlave struct {
+	Name      string
+	MIIStatus string
+	// active or backup.
+	State        string
+	LinkFailures *float64
+}
+
+// Bridge holds the ports of a Linux bridge.
+type Bridge struct {
+	Name string
+	// Nil when stp_state is missing or unreadable.
+	STPEnabled *bool
+	Ports      []BridgePort
+}
+
+// BridgePort holds the STP state of a bridge port, one of BridgePortStates
+// or the raw value of an unknown state.
+type BridgePort struct {
+	Name  string
+	State string
+}
+
+// Ethtool holds what the SIOCETHTOOL ioctl reports for a network device.
+// Parts the device does not support are nil.
+type Ethtool struct {
+	Device     string
+	DriverInfo *EthtoolDriverInfo
+	// Driver statistics as listed by ethtool -S.
+	Stats map[string]uint64
+	Link  *EthtoolLink
+}
+
+type EthtoolDriverInfo struct {
+	Driver          string
+	Version         string
+	FirmwareVersion string
+	BusInfo         string
+}
+
+type EthtoolLink struct {
+	// Bytes per second, nil if unknown.
+	Speed            *float64
+	Autoneg          bool
+	SupportedAutoneg bool
+	// Supported speed and duplex modes, such as 1000baseT/Full.
+	SupportedModes []string
+}
+
+// SockStat maps the protocols of /proc/net/sockstat{,6} to their fields,
+// e.g. SockStat["TCP"]["inuse"].
+type SockStat map[string]map[string]float64
+
+// Conntrack holds the usage of the netfilter connection tracking table.
+type Conntrack struct {
+	Entries      float64
+	EntriesLimit float64
+}
+
+// ConntrackStat holds the counters of one CPU from /proc/net/stat/nf_conntrack,
+// keyed by the field names of its header.
+type ConntrackStat struct {
+	CPU    string
+	Values map[string]float64
+}
+
+// TCPStat counts TCP sockets by state, and by local port and state for
+// the ports that were asked for.
+type TCPStat struct {
+	States map[string]*TCPStateStat
+	Ports  map[uint16]map[string]*TCPStateStat
+}
+
+// TCPStateStat holds the number of sockets in a state and the bytes in
+// their queues. For listening sockets the queues hold the accept backlog
+// and its limit instead.
+type TCPStateStat struct {
+	Count         float64
+	TransmitQueue float64
+	ReceiveQueue  float64
+}
+
+// NetLink holds the attributes and rtnl_link_stats64 counters of a network
+// device from an RTM_GETLINK dump. Stats use the keys of NetStat.
+type NetLink struct {
+	Index     uint32
+	Name      string
+	OperState string
+	MTU       uint32
+	// Name of the bond, bridge or VRF the device is enslaved to.
+	Master string
+	// Link type such as bond, bridge or veth, empty for physical devices.
+	Kind string
+	// Link type of the master, set for enslaved devices.
+	SlaveKind string
+	Stats     map[string]uint64
+}
+
+// ARPEntry is an IPv4 neighbour from an RTM_GETNEIGH dump. State is the
+// lower case NUD state, e.g. reachable or stale.
+type ARPEntry struct {
+	IP     string
+	Device string
+	State  string
+}
+
+// Route is a route from an RTM_GETROUTE dump. Table is the name of a
+// reserved table or the table id.
+type Route struct {
+	Family string
+	Table  string
+	// Set for unicast routes without a destination prefix.
+	Default bool
+	Metric  uint32
+	// A single path, or one per path of a multipath route.
+	NextHops []RouteNextHop
+}
+
+// RouteNextHop is a path of a route, either part may be empty.
+type RouteNextHop struct {
+	Gateway string
+	Device  string
+}
+
+// SoftIRQs maps each softirq vector of /proc/softirqs to its per-CPU counters.
+type SoftIRQs map[string]map[string]uint64
+
+// Interrupts maps each line of /proc/interrupts to its per-CPU counters.
+type Interrupts map[string]Interrupt
+
+type Interrupt struct {
+	// Interrupt controller, only set for numbered IRQs.
+	Type string
+	// Devices attached to the IRQ, or the description of a named interrupt.
+	Devices string
+	// Per-CPU counters keyed by CPU number, nil for summary lines.
+	Values map[string]uint64
+	// System wide count of summary lines such as ERR and MIS.
+	Total uint64
+	// Summary is set for lines that only carry a system wide count.
+	Summary bool
+}
+
+// VMStat maps the fields of /proc/vmstat to their values.
+type VMStat map[string]float64
+
 type UnameStat struct {
 	SysName    string
 	Release    string